@@ -3,11 +3,13 @@ package main
 import (
 	"booking-service/internal/config"
 	"booking-service/internal/infrastructure"
+	"booking-service/internal/messages"
 	"booking-service/internal/models"
 	"booking-service/internal/repository"
 	"booking-service/internal/services"
 	"booking-service/internal/transport"
 	"booking-service/internal/workers"
+	"context"
 	"os"
 
 	"github.com/gin-gonic/gin"
@@ -20,6 +22,7 @@ func main() {
 	db := config.Connect()
 
 	router := gin.Default()
+	router.Use(messages.Catalogue.Middleware())
 
 	if db == nil {
 		logger.Error("Database connection failed: database is nil")
@@ -28,7 +31,7 @@ func main() {
 
 	logger.Info("Database connected successfully")
 
-	if err := db.AutoMigrate(&models.Booking{}, &models.BookedSeat{}); err != nil {
+	if err := db.AutoMigrate(&models.Booking{}, &models.BookedSeat{}, &models.UserProfile{}, &models.OutboxEvent{}); err != nil {
 		logger.Error("Failed to migrate database", "error", err)
 		os.Exit(1)
 	}
@@ -40,10 +43,15 @@ func main() {
 
 	bookingRepo := repository.NewBookingRepository(db)
 	bookingSeatRepo := repository.NewBookingSeatRepository(db)
-	bookingService := services.NewBookingService(bookingRepo, bookingSeatRepo, db)
+	userProfileRepo := repository.NewUserProfileRepository(db)
+	outboxRepo := repository.NewOutboxRepository(db)
+	bookingService := services.NewBookingService(bookingRepo, bookingSeatRepo, userProfileRepo, outboxRepo, db)
 
 	go workers.StartExpiredBookingsWorker(bookingService)
 	go workers.StartEndedSessionsWorker(bookingService)
+	go workers.StartOutboxRelay(context.Background(), outboxRepo)
+	go infrastructure.StartUserEventsConsumer(context.Background(), bookingService)
+	go infrastructure.StartSessionEventsConsumer(context.Background(), bookingService)
 
 	transport.RegisterRoutes(router, bookingService)
 
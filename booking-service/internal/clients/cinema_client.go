@@ -50,3 +50,37 @@ func GetSession(sessionID uint) (*dto.SessionResponse, error) {
 
 	return &session, nil
 }
+
+// GetSessionSeatBlocks returns the permanent and session-specific seat blocks
+// in effect for a session.
+func GetSessionSeatBlocks(sessionID uint) ([]dto.SeatBlockResponse, error) {
+	var blocks []dto.SeatBlockResponse
+	if err := getJSON(fmt.Sprintf("%s/sessions/%d/seat-blocks", getCinemaServiceURL(), sessionID), &blocks); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// GetSessionSeats returns the seats of the session's hall with their
+// available/blocked state.
+func GetSessionSeats(sessionID uint) ([]dto.SessionSeat, error) {
+	var seats []dto.SessionSeat
+	if err := getJSON(fmt.Sprintf("%s/sessions/%d/seats", getCinemaServiceURL(), sessionID), &seats); err != nil {
+		return nil, err
+	}
+	return seats, nil
+}
+
+func getJSON(url string, out any) error {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("service returned status %d for %s", resp.StatusCode, url)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
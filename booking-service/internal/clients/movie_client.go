@@ -0,0 +1,23 @@
+package clients
+
+import (
+	"booking-service/internal/dto"
+	"fmt"
+	"os"
+)
+
+func getMovieServiceURL() string {
+	url := os.Getenv("MOVIE_SERVICE_URL")
+	if url == "" {
+		return "http://localhost:8083"
+	}
+	return url
+}
+
+func GetMovie(movieID uint) (*dto.MovieResponse, error) {
+	var movie dto.MovieResponse
+	if err := getJSON(fmt.Sprintf("%s/movies/%d", getMovieServiceURL(), movieID), &movie); err != nil {
+		return nil, err
+	}
+	return &movie, nil
+}
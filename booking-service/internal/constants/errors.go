@@ -9,3 +9,7 @@ var ErrBookingExpired = errors.New("the reservation time has expired")
 var ErrInvalidID = errors.New("invalid id")
 var ErrBookingAlreadyConfirmed = errors.New("booking already confirmed")
 var ErrInvalidBookingStatus = errors.New("invalid booking status")
+var ErrSessionNotBookable = errors.New("session is not open for booking")
+var ErrSeatsBlocked = errors.New("seats are blocked")
+var ErrAgeRestricted = errors.New("movie is restricted to viewers aged 18 and over")
+var ErrAgeUnverified = errors.New("birth date is required to book an 18+ movie")
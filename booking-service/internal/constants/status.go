@@ -15,8 +15,48 @@ type PaymentStatus string
 const (
 	PaymentPending PaymentStatus = "pending"
 	PaymentPaid    PaymentStatus = "paid"
+	// PaymentRefundPending marks a paid booking whose refund has been
+	// requested but not yet settled.
+	PaymentRefundPending PaymentStatus = "refund_pending"
 )
 
+type SeatState string
+
+const (
+	SeatAvailable SeatState = "available"
+	SeatBlocked   SeatState = "blocked"
+	SeatBooked    SeatState = "booked"
+)
+
+// SessionScheduled is the only cinema-service session status that accepts
+// new bookings.
+const SessionScheduled = "scheduled"
+
 const (
 	BookingTimeoutMinutes = 15
 )
+
+// BookingFinishedTopic carries the bookings whose session was attended.
+// movie-service relies on it to let the user review the movie.
+const BookingFinishedTopic = "booking.finished"
+
+// Topics of the events written to the outbox alongside booking changes.
+const (
+	BookingsTopic                  = "bookings"
+	RefundRequestedTopic           = "booking.refund_requested"
+	BookingSessionCancelledTopic   = "booking.session_cancelled"
+	BookingSessionRescheduledTopic = "booking.session_rescheduled"
+)
+
+// Refund reasons name the session event that made a refund due.
+const (
+	SessionCancelledReason   = "session.cancelled"
+	SessionRescheduledReason = "session.rescheduled"
+)
+
+// AdultAgeRating is the movie-service rating bookings are age checked for.
+// Younger ratings are advisory and left to the viewer.
+const (
+	AdultAgeRating = "18+"
+	AdultAge       = 18
+)
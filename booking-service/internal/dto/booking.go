@@ -2,6 +2,7 @@ package dto
 
 import (
 	"booking-service/internal/constants"
+	"encoding/json"
 	"time"
 )
 
@@ -9,6 +10,9 @@ type BookingCreateRequest struct {
 	SessionID uint   `json:"session_id" binding:"required"`
 	UserID    uint   `json:"user_id" binding:"required"`
 	SeatsID   []uint `json:"seats_id" binding:"required,min=1"`
+	// AgeOverride skips the age check after a cashier has seen the
+	// customer's ID. The gateway only accepts it from staff.
+	AgeOverride bool `json:"age_override"`
 }
 
 type BookingUpdateRequest struct {
@@ -23,14 +27,124 @@ type SessionResponse struct {
 	Status    string    `json:"status"`
 }
 
+type MovieResponse struct {
+	ID        uint   `json:"id"`
+	Title     string `json:"title"`
+	AgeRating string `json:"age_rating"`
+}
+
+type SeatBlockResponse struct {
+	SeatID    uint   `json:"seat_id"`
+	SessionID *uint  `json:"session_id,omitempty"`
+	Reason    string `json:"reason"`
+}
+
+type SessionSeat struct {
+	SeatID      uint                `json:"seat_id"`
+	Row         int                 `json:"row"`
+	Number      int                 `json:"number"`
+	Type        string              `json:"type"`
+	State       constants.SeatState `json:"state"`
+	BlockReason string              `json:"block_reason,omitempty"`
+}
+
 type BookingCreatedEvent struct {
+	BookingID     uint                     `json:"booking_id"`
 	SessionID     uint                     `json:"session_id"`
+	MovieID       uint                     `json:"movie_id"`
 	UserID        uint                     `json:"user_id"`
 	BookingStatus *constants.BookingStatus `json:"booking_status"`
 }
 
 type BookingCancelledEvent struct {
+	BookingID     uint                     `json:"booking_id"`
 	SessionID     uint                     `json:"session_id"`
+	MovieID       uint                     `json:"movie_id"`
 	UserID        uint                     `json:"user_id"`
 	BookingStatus *constants.BookingStatus `json:"booking_status"`
 }
+
+// UserEvent is the envelope user-service wraps its lifecycle events in.
+type UserEvent struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Version    int             `json:"version"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	UserID     uint            `json:"user_id"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// UserProfileData is the part of user.created and user.updated payloads
+// booking-service keeps. BirthDate uses the "2006-01-02" layout.
+type UserProfileData struct {
+	BirthDate string `json:"birth_date"`
+}
+
+type UserErasedData struct {
+	Pseudonym string `json:"pseudonym"`
+}
+
+type UserErasureCompletedEvent struct {
+	UserID    uint   `json:"user_id"`
+	Pseudonym string `json:"pseudonym"`
+	Bookings  int64  `json:"bookings"`
+}
+
+// SessionCancelledEvent is published by cinema-service when a session is
+// cancelled or deleted.
+type SessionCancelledEvent struct {
+	SessionID   uint      `json:"session_id"`
+	MovieID     uint      `json:"movie_id"`
+	HallID      uint      `json:"hall_id"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	CancelledAt time.Time `json:"cancelled_at"`
+}
+
+// BookingSessionCancelledEvent tells the booking owner that their booking
+// was cancelled together with the session.
+type BookingSessionCancelledEvent struct {
+	BookingID       uint                    `json:"booking_id"`
+	SessionID       uint                    `json:"session_id"`
+	UserID          uint                    `json:"user_id"`
+	PaymentStatus   constants.PaymentStatus `json:"payment_status"`
+	RefundRequested bool                    `json:"refund_requested"`
+}
+
+// BookingFinishedEvent is published once the session of a confirmed booking
+// is over, i.e. the user has seen the movie.
+type BookingFinishedEvent struct {
+	BookingID  uint      `json:"booking_id"`
+	UserID     uint      `json:"user_id"`
+	SessionID  uint      `json:"session_id"`
+	MovieID    uint      `json:"movie_id"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+type RefundRequestedEvent struct {
+	BookingID uint   `json:"booking_id"`
+	UserID    uint   `json:"user_id"`
+	Reason    string `json:"reason"`
+}
+
+// SessionRescheduledEvent is published by cinema-service when the times of a
+// session change.
+type SessionRescheduledEvent struct {
+	SessionID     uint      `json:"session_id"`
+	OldStartTime  time.Time `json:"old_start_time"`
+	OldEndTime    time.Time `json:"old_end_time"`
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time"`
+	RescheduledAt time.Time `json:"rescheduled_at"`
+}
+
+// BookingSessionRescheduledEvent tells the booking owner about the new times
+// and that they may cancel free of charge.
+type BookingSessionRescheduledEvent struct {
+	BookingID        uint      `json:"booking_id"`
+	SessionID        uint      `json:"session_id"`
+	UserID           uint      `json:"user_id"`
+	StartTime        time.Time `json:"start_time"`
+	EndTime          time.Time `json:"end_time"`
+	FreeCancellation bool      `json:"free_cancellation"`
+}
@@ -2,6 +2,7 @@ package infrastructure
 
 import (
 	"booking-service/internal/config"
+	"booking-service/internal/constants"
 	"booking-service/internal/dto"
 	"booking-service/internal/models"
 	"context"
@@ -16,9 +17,7 @@ import (
 	"github.com/segmentio/kafka-go"
 )
 
-const (
-	kafkaTopic = "bookings"
-)
+const kafkaTopic = constants.BookingsTopic
 
 func getKafkaBroker() string {
 	broker := os.Getenv("KAFKA_BROKER")
@@ -31,6 +30,9 @@ func getKafkaBroker() string {
 
 var kafkaWriter *kafka.Writer
 
+// eventsWriter has no fixed topic; each message names its own.
+var eventsWriter *kafka.Writer
+
 func createTopic() error {
 	kafkaBroker := getKafkaBroker()
 	conn, err := kafka.Dial("tcp", kafkaBroker)
@@ -100,56 +102,68 @@ func InitKafkaWriter() {
 		WriteTimeout: 10 * time.Second,
 		RequiredAcks: 1,
 	}
+	eventsWriter = &kafka.Writer{
+		Addr:                   kafka.TCP(kafkaBroker),
+		Balancer:               &kafka.Hash{},
+		WriteTimeout:           10 * time.Second,
+		RequiredAcks:           1,
+		AllowAutoTopicCreation: true,
+	}
 	config.GetLogger().Info("Kafka writer initialized successfully", "topic", kafkaTopic, "broker", kafkaBroker)
 }
 
-func PublishOrderCreated(booking models.Booking) error {
-	if kafkaWriter == nil {
+func publishEvent(topic, key string, event any) error {
+	if eventsWriter == nil {
 		config.GetLogger().Error("Kafka writer is not initialized")
 		return fmt.Errorf("kafka writer is not initialized")
 	}
 
-	event := dto.BookingCreatedEvent{
-		SessionID:     booking.SessionID,
-		UserID:        booking.UserID,
-		BookingStatus: &booking.BookingStatus,
-	}
-
 	eventJSON, err := json.Marshal(event)
 	if err != nil {
-		config.GetLogger().Error("Failed to marshal event", "error", err)
+		config.GetLogger().Error("Failed to marshal event", "error", err, "topic", topic)
 		return err
 	}
 
-	msg := kafka.Message{
-		Key:   []byte(fmt.Sprintf("booking-%d", booking.ID)),
-		Value: eventJSON,
+	return publishMessage(topic, key, eventJSON)
+}
+
+// PublishOutboxEvent publishes an event recorded in the outbox.
+func PublishOutboxEvent(event models.OutboxEvent) error {
+	if eventsWriter == nil {
+		config.GetLogger().Error("Kafka writer is not initialized")
+		return fmt.Errorf("kafka writer is not initialized")
 	}
 
+	return publishMessage(event.Topic, event.Key, event.Payload)
+}
+
+func publishMessage(topic, key string, payload []byte) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	err = kafkaWriter.WriteMessages(ctx, msg)
-	if err != nil {
-		config.GetLogger().Error("Failed to publish event to Kafka", "error", err, "booking_id", booking.ID)
+	if err := eventsWriter.WriteMessages(ctx, kafka.Message{
+		Topic: topic,
+		Key:   []byte(key),
+		Value: payload,
+	}); err != nil {
+		config.GetLogger().Error("Failed to publish event to Kafka", "error", err, "topic", topic, "key", key)
 		return err
 	}
 
-	config.GetLogger().Info("Booking created event published to Kafka",
-		"booking_id", booking.ID,
-		"topic", kafkaTopic,
-		"event_type", "created")
+	config.GetLogger().Info("Event published to Kafka", "topic", topic, "key", key)
 	return nil
 }
 
-func PublishOrderCancelled(booking models.Booking) error {
+func PublishOrderCreated(booking models.Booking) error {
 	if kafkaWriter == nil {
 		config.GetLogger().Error("Kafka writer is not initialized")
 		return fmt.Errorf("kafka writer is not initialized")
 	}
 
-	event := dto.BookingCancelledEvent{
+	event := dto.BookingCreatedEvent{
+		BookingID:     booking.ID,
 		SessionID:     booking.SessionID,
+		MovieID:       booking.MovieID,
 		UserID:        booking.UserID,
 		BookingStatus: &booking.BookingStatus,
 	}
@@ -174,9 +188,9 @@ func PublishOrderCancelled(booking models.Booking) error {
 		return err
 	}
 
-	config.GetLogger().Info("Booking cancelled event published to Kafka",
+	config.GetLogger().Info("Booking created event published to Kafka",
 		"booking_id", booking.ID,
 		"topic", kafkaTopic,
-		"event_type", "cancelled")
+		"event_type", "created")
 	return nil
 }
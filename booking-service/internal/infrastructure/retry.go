@@ -0,0 +1,20 @@
+package infrastructure
+
+import (
+	"booking-service/internal/config"
+	"context"
+	"shared/retry"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// handleWithRetry runs handle until it succeeds. Consumers commit only after
+// it returns, so an event that keeps failing holds up the events behind it
+// instead of being skipped. It returns false if ctx is done first.
+func handleWithRetry(ctx context.Context, msg kafka.Message, handle func() error) bool {
+	return retry.Until(ctx, handle, func(err error, attempt int, next time.Duration) {
+		config.GetLogger().Error("Failed to handle Kafka event, retrying",
+			"error", err, "topic", msg.Topic, "offset", msg.Offset, "attempt", attempt, "retry_in", next)
+	})
+}
@@ -0,0 +1,79 @@
+package infrastructure
+
+import (
+	"booking-service/internal/config"
+	"booking-service/internal/dto"
+	"booking-service/internal/services"
+	"context"
+	"encoding/json"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	sessionCancelledTopic   = "session.cancelled"
+	sessionRescheduledTopic = "session.rescheduled"
+)
+
+// StartSessionEventsConsumer reacts to session events from cinema-service.
+// Offsets are committed only after an event has been handled; events that
+// cannot be decoded are skipped.
+func StartSessionEventsConsumer(ctx context.Context, bookingService services.BookingService) {
+	logger := config.GetLogger()
+	topics := []string{sessionCancelledTopic, sessionRescheduledTopic}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     []string{getKafkaBroker()},
+		GroupTopics: topics,
+		GroupID:     consumerGroupID,
+	})
+	defer reader.Close()
+
+	logger.Info("Kafka consumer started", "topics", topics, "group", consumerGroupID)
+
+	for {
+		msg, err := reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				logger.Info("Kafka consumer stopped", "topics", topics)
+				return
+			}
+			logger.Error("Failed to read Kafka message", "error", err)
+			continue
+		}
+
+		if !handleWithRetry(ctx, msg, func() error { return handleSessionEvent(bookingService, msg) }) {
+			logger.Info("Kafka consumer stopped", "topics", topics)
+			return
+		}
+
+		if err := reader.CommitMessages(ctx, msg); err != nil {
+			logger.Error("Failed to commit Kafka message", "error", err, "offset", msg.Offset)
+		}
+	}
+}
+
+func handleSessionEvent(bookingService services.BookingService, msg kafka.Message) error {
+	switch msg.Topic {
+	case sessionCancelledTopic:
+		var event dto.SessionCancelledEvent
+		if err := json.Unmarshal(msg.Value, &event); err != nil {
+			config.GetLogger().Error("Failed to decode session event, skipping", "error", err, "topic", msg.Topic, "offset", msg.Offset)
+			return nil
+		}
+
+		return bookingService.CancelSessionBookings(event.SessionID)
+
+	case sessionRescheduledTopic:
+		var event dto.SessionRescheduledEvent
+		if err := json.Unmarshal(msg.Value, &event); err != nil {
+			config.GetLogger().Error("Failed to decode session event, skipping", "error", err, "topic", msg.Topic, "offset", msg.Offset)
+			return nil
+		}
+
+		return bookingService.RescheduleSessionBookings(event.SessionID, event.StartTime, event.EndTime)
+
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,132 @@
+package infrastructure
+
+import (
+	"booking-service/internal/config"
+	"booking-service/internal/dto"
+	"booking-service/internal/services"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	userCreatedTopic          = "user.created"
+	userUpdatedTopic          = "user.updated"
+	userDeletedTopic          = "user.deleted"
+	userErasedTopic           = "user.erased"
+	userErasureCompletedTopic = "booking.user_erased"
+	consumerGroupID           = "booking-service"
+)
+
+// StartUserEventsConsumer reacts to user lifecycle events from user-service.
+// Offsets are committed only after an event has been handled; events that
+// cannot be decoded are skipped.
+func StartUserEventsConsumer(ctx context.Context, bookingService services.BookingService) {
+	logger := config.GetLogger()
+	topics := []string{userCreatedTopic, userUpdatedTopic, userDeletedTopic, userErasedTopic}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     []string{getKafkaBroker()},
+		GroupTopics: topics,
+		GroupID:     consumerGroupID,
+	})
+	defer reader.Close()
+
+	logger.Info("Kafka consumer started", "topics", topics, "group", consumerGroupID)
+
+	for {
+		msg, err := reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				logger.Info("Kafka consumer stopped", "topics", topics)
+				return
+			}
+			logger.Error("Failed to read Kafka message", "error", err)
+			continue
+		}
+
+		handled := handleWithRetry(ctx, msg, func() error {
+			var event dto.UserEvent
+			if err := json.Unmarshal(msg.Value, &event); err != nil {
+				logger.Error("Failed to decode user event, skipping", "error", err, "topic", msg.Topic, "offset", msg.Offset)
+				return nil
+			}
+			return handleUserEvent(bookingService, event)
+		})
+		if !handled {
+			logger.Info("Kafka consumer stopped", "topics", topics)
+			return
+		}
+
+		if err := reader.CommitMessages(ctx, msg); err != nil {
+			logger.Error("Failed to commit Kafka message", "error", err, "offset", msg.Offset)
+		}
+	}
+}
+
+// handleUserEvent is retried until it succeeds, so malformed events are
+// logged and skipped rather than returned as errors.
+func handleUserEvent(bookingService services.BookingService, event dto.UserEvent) error {
+	logger := config.GetLogger()
+
+	switch event.Type {
+	case userCreatedTopic, userUpdatedTopic:
+		var data dto.UserProfileData
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			logger.Error("Failed to decode user profile, skipping", "error", err, "type", event.Type, "user_id", event.UserID)
+			return nil
+		}
+
+		var birthDate *time.Time
+		if data.BirthDate != "" {
+			date, err := time.Parse("2006-01-02", data.BirthDate)
+			if err != nil {
+				logger.Error("Invalid birth date in user event, skipping", "error", err, "type", event.Type, "user_id", event.UserID)
+				return nil
+			}
+			birthDate = &date
+		}
+		return bookingService.SyncUserProfile(event.UserID, birthDate)
+
+	case userDeletedTopic:
+		if err := bookingService.CancelUserBookings(event.UserID); err != nil {
+			return err
+		}
+		return bookingService.ForgetUserProfile(event.UserID)
+
+	case userErasedTopic:
+		var data dto.UserErasedData
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			logger.Error("Failed to decode user erasure, skipping", "error", err, "user_id", event.UserID)
+			return nil
+		}
+		if data.Pseudonym == "" {
+			logger.Error("User erased event without pseudonym, skipping", "user_id", event.UserID)
+			return nil
+		}
+
+		count, err := bookingService.EraseUser(event.UserID, data.Pseudonym)
+		if err != nil {
+			return err
+		}
+		if err := bookingService.ForgetUserProfile(event.UserID); err != nil {
+			return err
+		}
+
+		return publishErasureCompleted(dto.UserErasureCompletedEvent{
+			UserID:    event.UserID,
+			Pseudonym: data.Pseudonym,
+			Bookings:  count,
+		})
+
+	default:
+		return nil
+	}
+}
+
+func publishErasureCompleted(event dto.UserErasureCompletedEvent) error {
+	return publishEvent(userErasureCompletedTopic, fmt.Sprintf("user-%d", event.UserID), event)
+}
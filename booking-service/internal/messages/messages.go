@@ -0,0 +1,36 @@
+// Package messages lists the error messages the service responds with and
+// their translations.
+package messages
+
+import "shared/i18n"
+
+// Catalogue translates the service's error responses.
+var Catalogue = i18n.NewCatalogue(english, map[string]map[string]string{
+	"ru": ru,
+})
+
+// english maps message IDs to the text responses carry. Translations refer
+// to the IDs, so a message can be reworded here alone.
+var english = map[string]string{
+	// handlers
+	"request.invalid_id":      "invalid id",
+	"request.invalid_json":    "invalid JSON",
+	"request.invalid_user_id": "invalid user id",
+
+	// services
+	"booking.age_restricted":    "movie is restricted to viewers aged 18 and over",
+	"booking.age_unverified":    "birth date is required to book an 18+ movie",
+	"booking.already_cancelled": "booking already cancelled",
+	"booking.already_confirmed": "booking already confirmed",
+	"booking.expired":           "the reservation time has expired",
+	"booking.invalid_status":    "invalid booking status",
+	"booking.not_found":         "booking not found",
+	"booking.not_pending":       "booking is not in pending status",
+	"booking.update_failed":     "failed update booking",
+	"seats.already_booked":      "seats already booked",
+	"seats.blocked":             "seats are blocked",
+	"seats.empty":               "seats list cannot be empty",
+	"session.not_found":         "session not found",
+	"session.not_open":          "session is not open for booking",
+	"session.started":           "session already started",
+}
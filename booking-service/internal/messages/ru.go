@@ -0,0 +1,25 @@
+package messages
+
+var ru = map[string]string{
+	// handlers
+	"request.invalid_id":      "некорректный id",
+	"request.invalid_json":    "некорректный JSON",
+	"request.invalid_user_id": "некорректный id пользователя",
+
+	// services
+	"booking.age_restricted":    "фильм доступен только зрителям от 18 лет",
+	"booking.age_unverified":    "для бронирования фильма 18+ нужна дата рождения",
+	"booking.already_cancelled": "бронирование уже отменено",
+	"booking.already_confirmed": "бронирование уже подтверждено",
+	"booking.expired":           "время брони истекло",
+	"booking.invalid_status":    "некорректный статус бронирования",
+	"booking.not_found":         "бронирование не найдено",
+	"booking.not_pending":       "бронирование не ожидает подтверждения",
+	"booking.update_failed":     "не удалось обновить бронирование",
+	"seats.already_booked":      "места уже забронированы",
+	"seats.blocked":             "места заблокированы",
+	"seats.empty":               "список мест не может быть пустым",
+	"session.not_found":         "сеанс не найден",
+	"session.not_open":          "бронирование на сеанс закрыто",
+	"session.started":           "сеанс уже начался",
+}
@@ -9,7 +9,9 @@ type Booking struct {
 	Base
 
 	SessionID     uint                    `json:"session_id" gorm:"not null;index"`
+	MovieID       uint                    `json:"movie_id" gorm:"index"`
 	UserID        uint                    `json:"user_id" gorm:"not null;index"`
+	UserPseudonym string                  `json:"user_pseudonym,omitempty" gorm:"type:varchar(64);index"`
 	BookingStatus constants.BookingStatus `json:"booking_status" gorm:"default:pending;index"`
 	PaymentStatus constants.PaymentStatus `json:"payment_status" gorm:"default:pending;index"`
 	ExpiresAt     time.Time               `json:"expires_at" gorm:"not null;index"`
@@ -17,6 +19,13 @@ type Booking struct {
 
 	SessionStartTime time.Time `json:"session_start_time" gorm:"not null;index"`
 	SessionEndTime   time.Time `json:"session_end_time" gorm:"not null;index"`
+
+	// RescheduledAt is set when the session moved after the booking was made;
+	// such bookings may be cancelled free of charge until the new start.
+	RescheduledAt *time.Time `json:"rescheduled_at,omitempty"`
+
+	// AgeOverride records that a cashier waived the age check.
+	AgeOverride bool `json:"age_override,omitempty"`
 }
 
 type BookedSeat struct {
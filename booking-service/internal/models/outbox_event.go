@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// OutboxEvent is an event written in the same transaction as the change it
+// describes and published to Kafka afterwards by the outbox relay.
+type OutboxEvent struct {
+	ID          uint       `gorm:"primaryKey"`
+	Topic       string     `gorm:"type:varchar(100);not null"`
+	Key         string     `gorm:"type:varchar(100);not null"`
+	Payload     []byte     `gorm:"type:jsonb;not null"`
+	CreatedAt   time.Time  `gorm:"not null"`
+	PublishedAt *time.Time `gorm:"index"`
+	Attempts    int        `gorm:"not null;default:0"`
+	LastError   string     `gorm:"type:text"`
+}
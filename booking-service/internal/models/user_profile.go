@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// UserProfile is the part of a user-service account booking-service needs
+// locally, kept in sync from user lifecycle events.
+type UserProfile struct {
+	UserID    uint       `json:"user_id" gorm:"primarykey;autoIncrement:false"`
+	BirthDate *time.Time `json:"birth_date,omitempty" gorm:"type:date"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
@@ -20,8 +20,13 @@ type BookingRepository interface {
 	UpdateWithTx(tx *gorm.DB, id uint, req models.Booking) error
 	Delete(id uint) error
 	CheckBooked(tx *gorm.DB, sessionID uint, seatsID []uint) ([]uint, error)
+	BookedSeatIDs(sessionID uint) ([]uint, error)
 	FindExpiredPendingBookings() ([]models.Booking, error)
 	FindBookingsForEndedSessions() ([]models.Booking, error)
+	FindPendingByUserID(userID uint) ([]models.Booking, error)
+	FindActiveBySessionID(tx *gorm.DB, sessionID uint) ([]models.Booking, error)
+	ListByUserID(userID uint) ([]models.Booking, error)
+	PseudonymizeUser(userID uint, pseudonym string) (int64, error)
 }
 
 type gormBookingRepository struct {
@@ -138,6 +143,26 @@ func (r *gormBookingRepository) CheckBooked(tx *gorm.DB, sessionID uint, seatIDs
 	return bookedSeatIDs, nil
 }
 
+// BookedSeatIDs lists the seats held by pending or confirmed bookings of a
+// session, without locking them.
+func (r *gormBookingRepository) BookedSeatIDs(sessionID uint) ([]uint, error) {
+	var seatIDs = []uint{}
+
+	err := r.db.
+		Model(&models.BookedSeat{}).
+		Joins("JOIN bookings ON booked_seats.booking_id = bookings.id").
+		Where("bookings.session_id = ? AND bookings.booking_status IN (?, ?)",
+			sessionID, constants.Pending, constants.Confirmed).
+		Pluck("booked_seats.seat_id", &seatIDs).Error
+
+	if err != nil {
+		config.GetLogger().Error("Failed to list booked seats", "error", err, "session_id", sessionID)
+		return nil, err
+	}
+
+	return seatIDs, nil
+}
+
 func (r *gormBookingRepository) FindExpiredPendingBookings() ([]models.Booking, error) {
 	var bookings []models.Booking
 
@@ -168,3 +193,65 @@ func (r *gormBookingRepository) FindBookingsForEndedSessions() ([]models.Booking
 
 	return bookings, nil
 }
+
+func (r *gormBookingRepository) FindPendingByUserID(userID uint) ([]models.Booking, error) {
+	var bookings []models.Booking
+
+	err := r.db.
+		Where("user_id = ? AND booking_status = ?", userID, constants.Pending).
+		Find(&bookings).Error
+
+	if err != nil {
+		config.GetLogger().Error("Failed to find pending bookings for user", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	return bookings, nil
+}
+
+// FindActiveBySessionID locks the pending and confirmed bookings of a session.
+func (r *gormBookingRepository) FindActiveBySessionID(tx *gorm.DB, sessionID uint) ([]models.Booking, error) {
+	var bookings []models.Booking
+
+	err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("session_id = ? AND booking_status IN (?, ?)", sessionID, constants.Pending, constants.Confirmed).
+		Find(&bookings).Error
+
+	if err != nil {
+		config.GetLogger().Error("Failed to find active bookings for session", "error", err, "session_id", sessionID)
+		return nil, err
+	}
+
+	return bookings, nil
+}
+
+func (r *gormBookingRepository) ListByUserID(userID uint) ([]models.Booking, error) {
+	var bookings []models.Booking
+
+	if err := r.db.Preload("BookedSeats").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&bookings).Error; err != nil {
+		config.GetLogger().Error("Failed to list bookings for user", "error", err, "user_id", userID)
+		return nil, err
+	}
+
+	return bookings, nil
+}
+
+// PseudonymizeUser detaches every booking of the user, including soft-deleted
+// ones, from the real user id while keeping the payment history intact.
+func (r *gormBookingRepository) PseudonymizeUser(userID uint, pseudonym string) (int64, error) {
+	res := r.db.Unscoped().
+		Model(&models.Booking{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]any{"user_id": 0, "user_pseudonym": pseudonym})
+
+	if res.Error != nil {
+		config.GetLogger().Error("Failed to pseudonymize user bookings", "error", res.Error, "user_id", userID)
+		return 0, res.Error
+	}
+
+	return res.RowsAffected, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"booking-service/internal/config"
+	"booking-service/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type OutboxRepository interface {
+	Add(tx *gorm.DB, events ...models.OutboxEvent) error
+	ListPending(limit int) ([]models.OutboxEvent, error)
+	MarkPublished(id uint) error
+	MarkFailed(id uint, cause error) error
+}
+
+type gormOutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &gormOutboxRepository{
+		db: db,
+	}
+}
+
+func (r *gormOutboxRepository) Add(tx *gorm.DB, events ...models.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	if err := tx.Create(&events).Error; err != nil {
+		config.GetLogger().Error("Failed to add outbox events", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *gormOutboxRepository) ListPending(limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+
+	if err := r.db.Where("published_at IS NULL").
+		Order("id").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		config.GetLogger().Error("Failed to list pending outbox events", "error", err)
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *gormOutboxRepository) MarkPublished(id uint) error {
+	if err := r.db.Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		Update("published_at", time.Now()).Error; err != nil {
+		config.GetLogger().Error("Failed to mark outbox event published", "error", err, "id", id)
+		return err
+	}
+
+	return nil
+}
+
+func (r *gormOutboxRepository) MarkFailed(id uint, cause error) error {
+	if err := r.db.Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": cause.Error(),
+		}).Error; err != nil {
+		config.GetLogger().Error("Failed to mark outbox event failed", "error", err, "id", id)
+		return err
+	}
+
+	return nil
+}
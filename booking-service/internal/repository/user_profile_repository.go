@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"booking-service/internal/config"
+	"booking-service/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UserProfileRepository interface {
+	Upsert(profile *models.UserProfile) error
+	GetByUserID(userID uint) (*models.UserProfile, error)
+	Delete(userID uint) error
+}
+
+type gormUserProfileRepository struct {
+	db *gorm.DB
+}
+
+func NewUserProfileRepository(db *gorm.DB) UserProfileRepository {
+	return &gormUserProfileRepository{
+		db: db,
+	}
+}
+
+func (r *gormUserProfileRepository) Upsert(profile *models.UserProfile) error {
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"birth_date", "updated_at"}),
+	}).Create(profile).Error; err != nil {
+		config.GetLogger().Error("Failed to save user profile", "error", err, "user_id", profile.UserID)
+		return err
+	}
+
+	return nil
+}
+
+func (r *gormUserProfileRepository) GetByUserID(userID uint) (*models.UserProfile, error) {
+	var profile models.UserProfile
+
+	if err := r.db.Where("user_id = ?", userID).First(&profile).Error; err != nil {
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+func (r *gormUserProfileRepository) Delete(userID uint) error {
+	if err := r.db.Where("user_id = ?", userID).Delete(&models.UserProfile{}).Error; err != nil {
+		config.GetLogger().Error("Failed to delete user profile", "error", err, "user_id", userID)
+		return err
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"booking-service/internal/dto"
 	"booking-service/internal/models"
 	"booking-service/internal/repository"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -26,18 +27,30 @@ type BookingService interface {
 	ExpireOldBookings() error
 	FreeSeatsForEndedSessions() error
 	ExpireBooking(id uint) (*models.Booking, error)
+	CancelUserBookings(userID uint) error
+	ListByUserID(userID uint) ([]models.Booking, error)
+	EraseUser(userID uint, pseudonym string) (int64, error)
+	CancelSessionBookings(sessionID uint) error
+	RescheduleSessionBookings(sessionID uint, start, end time.Time) error
+	SessionAvailability(sessionID uint) ([]dto.SessionSeat, error)
+	SyncUserProfile(userID uint, birthDate *time.Time) error
+	ForgetUserProfile(userID uint) error
 }
 
 type bookingService struct {
 	bookingRepo     repository.BookingRepository
 	bookingSeatRepo repository.BookingSeatRepository
+	userProfileRepo repository.UserProfileRepository
+	outboxRepo      repository.OutboxRepository
 	db              *gorm.DB
 }
 
-func NewBookingService(bookingRepo repository.BookingRepository, bookingSeatRepo repository.BookingSeatRepository, db *gorm.DB) BookingService {
+func NewBookingService(bookingRepo repository.BookingRepository, bookingSeatRepo repository.BookingSeatRepository, userProfileRepo repository.UserProfileRepository, outboxRepo repository.OutboxRepository, db *gorm.DB) BookingService {
 	return &bookingService{
 		bookingRepo:     bookingRepo,
 		bookingSeatRepo: bookingSeatRepo,
+		userProfileRepo: userProfileRepo,
+		outboxRepo:      outboxRepo,
 		db:              db,
 	}
 }
@@ -69,11 +82,35 @@ func (s *bookingService) Create(req dto.BookingCreateRequest) (*models.Booking,
 		return nil, fmt.Errorf("session not found")
 	}
 
+	if session.Status != constants.SessionScheduled {
+		tx.Rollback()
+		config.GetLogger().Warn("Attempt to book non-scheduled session", "session_id", req.SessionID, "status", session.Status)
+		return nil, fmt.Errorf("%w: status is %s", constants.ErrSessionNotBookable, session.Status)
+	}
+
 	if !session.StartTime.After(time.Now()) {
 		tx.Rollback()
 		return nil, fmt.Errorf("session already started")
 	}
 
+	if req.AgeOverride {
+		config.GetLogger().Info("Age check overridden by cashier", "session_id", req.SessionID, "user_id", req.UserID)
+	} else if err := s.checkAge(req.UserID, session); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	blocks, err := clients.GetSessionSeatBlocks(req.SessionID)
+	if err != nil {
+		tx.Rollback()
+		config.GetLogger().Error("Failed to get seat blocks", "error", err, "session_id", req.SessionID)
+		return nil, err
+	}
+	if blocked := blockedSeats(blocks, req.SeatsID); len(blocked) > 0 {
+		tx.Rollback()
+		return nil, fmt.Errorf("%w: %v", constants.ErrSeatsBlocked, blocked)
+	}
+
 	bookedSeats, err := s.bookingRepo.CheckBooked(tx, req.SessionID, req.SeatsID)
 	if err != nil {
 		tx.Rollback()
@@ -87,12 +124,14 @@ func (s *bookingService) Create(req dto.BookingCreateRequest) (*models.Booking,
 
 	var booking = models.Booking{
 		SessionID:        req.SessionID,
+		MovieID:          session.MovieID,
 		UserID:           req.UserID,
 		BookingStatus:    constants.Pending,
 		PaymentStatus:    constants.PaymentPending,
 		ExpiresAt:        time.Now().Add(constants.BookingTimeoutMinutes * time.Minute),
 		SessionStartTime: session.StartTime,
 		SessionEndTime:   session.EndTime,
+		AgeOverride:      req.AgeOverride,
 	}
 
 	newBooking, err := s.bookingRepo.Create(tx, &booking)
@@ -274,6 +313,15 @@ func (s *bookingService) CancelBooking(id uint) (*models.Booking, error) {
 		return nil, constants.ErrBookingAlreadyCancelled
 	case constants.Pending:
 		booking.BookingStatus = constants.Cancelled
+	case constants.Confirmed:
+		if !freeCancellation(booking) {
+			tx.Rollback()
+			return nil, constants.ErrInvalidBookingStatus
+		}
+		booking.BookingStatus = constants.Cancelled
+		if booking.PaymentStatus == constants.PaymentPaid {
+			booking.PaymentStatus = constants.PaymentRefundPending
+		}
 	default:
 		tx.Rollback()
 		return nil, constants.ErrInvalidBookingStatus
@@ -296,6 +344,19 @@ func (s *bookingService) CancelBooking(id uint) (*models.Booking, error) {
 		return nil, err
 	}
 
+	if err := s.addBookingCancelled(tx, updatedBooking); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// only a rescheduled session lets a paid booking be cancelled
+	if updatedBooking.PaymentStatus == constants.PaymentRefundPending {
+		if err := s.addRefundRequested(tx, updatedBooking, constants.SessionRescheduledReason); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		tx.Rollback()
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
@@ -389,6 +450,9 @@ func (s *bookingService) ExpireOldBookings() error {
 	return nil
 }
 
+// FreeSeatsForEndedSessions settles the bookings of sessions that are over.
+// Attended bookings move to finished, and their booking.finished event is
+// written to the outbox in the same transaction.
 func (s *bookingService) FreeSeatsForEndedSessions() error {
 	endedSessionsBookings, err := s.bookingRepo.FindBookingsForEndedSessions()
 	if err != nil {
@@ -431,6 +495,15 @@ func (s *bookingService) FreeSeatsForEndedSessions() error {
 		}
 
 		currentBooking.BookingStatus = finalStatus
+		if finalStatus == constants.Finished && currentBooking.MovieID == 0 {
+			// bookings made before the movie was recorded on them
+			if session, err := clients.GetSession(currentBooking.SessionID); err == nil {
+				currentBooking.MovieID = session.MovieID
+			} else {
+				config.GetLogger().Warn("Failed to look up movie of finished booking",
+					"error", err, "booking_id", booking.ID, "session_id", currentBooking.SessionID)
+			}
+		}
 		if err := s.bookingRepo.UpdateWithTx(tx, currentBooking.ID, *currentBooking); err != nil {
 			tx.Rollback()
 			config.GetLogger().Error("Failed to update booking status for ended session",
@@ -445,6 +518,15 @@ func (s *bookingService) FreeSeatsForEndedSessions() error {
 			continue
 		}
 
+		if finalStatus == constants.Finished {
+			if err := s.addBookingFinished(tx, currentBooking); err != nil {
+				tx.Rollback()
+				config.GetLogger().Error("Failed to record finished booking event",
+					"error", err, "booking_id", booking.ID)
+				continue
+			}
+		}
+
 		if err := tx.Commit().Error; err != nil {
 			tx.Rollback()
 			config.GetLogger().Error("Failed to commit transaction for ended session",
@@ -461,3 +543,350 @@ func (s *bookingService) FreeSeatsForEndedSessions() error {
 
 	return nil
 }
+
+func (s *bookingService) addBookingFinished(tx *gorm.DB, booking *models.Booking) error {
+	return s.addOutboxEvent(tx, constants.BookingFinishedTopic, booking.ID, dto.BookingFinishedEvent{
+		BookingID:  booking.ID,
+		UserID:     booking.UserID,
+		SessionID:  booking.SessionID,
+		MovieID:    booking.MovieID,
+		FinishedAt: booking.SessionEndTime,
+	})
+}
+
+func (s *bookingService) addBookingCancelled(tx *gorm.DB, booking *models.Booking) error {
+	return s.addOutboxEvent(tx, constants.BookingsTopic, booking.ID, dto.BookingCancelledEvent{
+		BookingID:     booking.ID,
+		SessionID:     booking.SessionID,
+		MovieID:       booking.MovieID,
+		UserID:        booking.UserID,
+		BookingStatus: &booking.BookingStatus,
+	})
+}
+
+func (s *bookingService) addRefundRequested(tx *gorm.DB, booking *models.Booking, reason string) error {
+	return s.addOutboxEvent(tx, constants.RefundRequestedTopic, booking.ID, dto.RefundRequestedEvent{
+		BookingID: booking.ID,
+		UserID:    booking.UserID,
+		Reason:    reason,
+	})
+}
+
+// addOutboxEvent records an event about a booking in tx, so it is published
+// only if the change it describes is committed.
+func (s *bookingService) addOutboxEvent(tx *gorm.DB, topic string, bookingID uint, event any) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return s.outboxRepo.Add(tx, models.OutboxEvent{
+		Topic:   topic,
+		Key:     fmt.Sprintf("booking-%d", bookingID),
+		Payload: payload,
+	})
+}
+
+// CancelUserBookings cancels the user's pending bookings. A booking that
+// cannot be cancelled does not stop the others; the errors are returned
+// together and calling it again picks up the ones still pending.
+func (s *bookingService) CancelUserBookings(userID uint) error {
+	pending, err := s.bookingRepo.FindPendingByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	cancelled := 0
+	var errs []error
+	for _, booking := range pending {
+		if _, err := s.CancelBooking(booking.ID); err != nil {
+			config.GetLogger().Error("Failed to cancel booking of user",
+				"error", err, "booking_id", booking.ID, "user_id", userID)
+			errs = append(errs, fmt.Errorf("cancel booking %d: %w", booking.ID, err))
+			continue
+		}
+		cancelled++
+	}
+
+	config.GetLogger().Info("Pending bookings of user cancelled",
+		"user_id", userID, "count", cancelled, "failed", len(errs))
+
+	return errors.Join(errs...)
+}
+
+func (s *bookingService) ListByUserID(userID uint) ([]models.Booking, error) {
+	bookings, err := s.bookingRepo.ListByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return bookings, nil
+}
+
+// EraseUser cancels the user's pending bookings and replaces the user id on
+// the whole booking history with a pseudonym. Payment data is kept.
+func (s *bookingService) EraseUser(userID uint, pseudonym string) (int64, error) {
+	if err := s.CancelUserBookings(userID); err != nil {
+		return 0, err
+	}
+
+	count, err := s.bookingRepo.PseudonymizeUser(userID, pseudonym)
+	if err != nil {
+		return 0, err
+	}
+
+	config.GetLogger().Info("User bookings pseudonymized", "count", count)
+	return count, nil
+}
+
+// CancelSessionBookings cancels every pending and confirmed booking of a
+// cancelled session in one transaction and frees their seats. Paid bookings
+// are moved to refund_pending. The events about the cancelled bookings are
+// written to the outbox in the same transaction. Bookings already handled are
+// skipped, so a redelivered event is a no-op.
+func (s *bookingService) CancelSessionBookings(sessionID uint) error {
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		config.GetLogger().Error("Failed to start transaction for session cancel", "error", tx.Error, "session_id", sessionID)
+		return tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		}
+	}()
+
+	bookings, err := s.bookingRepo.FindActiveBySessionID(tx, sessionID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for i := range bookings {
+		booking := &bookings[i]
+		booking.BookingStatus = constants.Cancelled
+		if booking.PaymentStatus == constants.PaymentPaid {
+			booking.PaymentStatus = constants.PaymentRefundPending
+		}
+
+		if err := s.bookingRepo.UpdateWithTx(tx, booking.ID, *booking); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := s.bookingSeatRepo.DeleteByBookingID(tx, booking.ID); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := s.addSessionCancelled(tx, booking); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	config.GetLogger().Info("Bookings of cancelled session cancelled",
+		"session_id", sessionID, "count", len(bookings))
+
+	return nil
+}
+
+// addSessionCancelled records the events for a booking cancelled with its
+// session: the cancellation itself, the notice to the user and, for a paid
+// booking, the refund request.
+func (s *bookingService) addSessionCancelled(tx *gorm.DB, booking *models.Booking) error {
+	if err := s.addBookingCancelled(tx, booking); err != nil {
+		return err
+	}
+
+	refund := booking.PaymentStatus == constants.PaymentRefundPending
+	if err := s.addOutboxEvent(tx, constants.BookingSessionCancelledTopic, booking.ID, dto.BookingSessionCancelledEvent{
+		BookingID:       booking.ID,
+		SessionID:       booking.SessionID,
+		UserID:          booking.UserID,
+		PaymentStatus:   booking.PaymentStatus,
+		RefundRequested: refund,
+	}); err != nil {
+		return err
+	}
+
+	if refund {
+		return s.addRefundRequested(tx, booking, constants.SessionCancelledReason)
+	}
+	return nil
+}
+
+// RescheduleSessionBookings copies the new session times onto its pending and
+// confirmed bookings and marks them eligible for free cancellation. The
+// notices to the users are written to the outbox in the same transaction.
+// Bookings that already carry the new times are skipped, so redelivery is a
+// no-op.
+func (s *bookingService) RescheduleSessionBookings(sessionID uint, start, end time.Time) error {
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		config.GetLogger().Error("Failed to start transaction for session reschedule", "error", tx.Error, "session_id", sessionID)
+		return tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		}
+	}()
+
+	bookings, err := s.bookingRepo.FindActiveBySessionID(tx, sessionID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	now := time.Now()
+	rescheduled := 0
+	for _, booking := range bookings {
+		if booking.SessionStartTime.Equal(start) && booking.SessionEndTime.Equal(end) {
+			continue
+		}
+
+		booking.SessionStartTime = start
+		booking.SessionEndTime = end
+		booking.RescheduledAt = &now
+
+		if err := s.bookingRepo.UpdateWithTx(tx, booking.ID, booking); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := s.addOutboxEvent(tx, constants.BookingSessionRescheduledTopic, booking.ID, dto.BookingSessionRescheduledEvent{
+			BookingID:        booking.ID,
+			SessionID:        booking.SessionID,
+			UserID:           booking.UserID,
+			StartTime:        booking.SessionStartTime,
+			EndTime:          booking.SessionEndTime,
+			FreeCancellation: true,
+		}); err != nil {
+			tx.Rollback()
+			return err
+		}
+		rescheduled++
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	config.GetLogger().Info("Bookings of rescheduled session updated",
+		"session_id", sessionID, "count", rescheduled)
+
+	return nil
+}
+
+// freeCancellation reports whether a confirmed booking may still be cancelled
+// because its session was rescheduled and has not started yet.
+func freeCancellation(booking *models.Booking) bool {
+	return booking.RescheduledAt != nil && booking.SessionStartTime.After(time.Now())
+}
+
+// SessionAvailability combines the hall seats and blocks from cinema-service
+// with the seats held by bookings. A booking wins over a block added later.
+func (s *bookingService) SessionAvailability(sessionID uint) ([]dto.SessionSeat, error) {
+	seats, err := clients.GetSessionSeats(sessionID)
+	if err != nil {
+		config.GetLogger().Error("Failed to get session seats", "error", err, "session_id", sessionID)
+		return nil, err
+	}
+
+	booked, err := s.bookingRepo.BookedSeatIDs(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	held := make(map[uint]bool, len(booked))
+	for _, id := range booked {
+		held[id] = true
+	}
+
+	for i := range seats {
+		if held[seats[i].SeatID] {
+			seats[i].State = constants.SeatBooked
+			seats[i].BlockReason = ""
+		}
+	}
+
+	return seats, nil
+}
+
+func blockedSeats(blocks []dto.SeatBlockResponse, seatIDs []uint) []uint {
+	blocked := make(map[uint]bool, len(blocks))
+	for _, block := range blocks {
+		blocked[block.SeatID] = true
+	}
+
+	var result []uint
+	for _, id := range seatIDs {
+		if blocked[id] {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// checkAge refuses 18+ movies to users who are younger at the session start
+// or whose birth date is unknown. Accounts created before birth dates were
+// collected have none until the user sets it once through PATCH /me (or staff
+// set it), and get ErrAgeUnverified for 18+ movies until then.
+func (s *bookingService) checkAge(userID uint, session *dto.SessionResponse) error {
+	movie, err := clients.GetMovie(session.MovieID)
+	if err != nil {
+		config.GetLogger().Error("Failed to get movie for age check", "error", err, "movie_id", session.MovieID)
+		return err
+	}
+
+	if movie.AgeRating != constants.AdultAgeRating {
+		return nil
+	}
+
+	profile, err := s.userProfileRepo.GetByUserID(userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if profile == nil || profile.BirthDate == nil {
+		config.GetLogger().Warn("Age check failed: unknown birth date", "user_id", userID, "movie_id", movie.ID)
+		return constants.ErrAgeUnverified
+	}
+
+	if ageAt(*profile.BirthDate, session.StartTime) < constants.AdultAge {
+		config.GetLogger().Warn("Age check failed: user is underage", "user_id", userID, "movie_id", movie.ID)
+		return constants.ErrAgeRestricted
+	}
+
+	return nil
+}
+
+// ageAt returns the age in full years on the given day.
+func ageAt(birthDate, day time.Time) int {
+	age := day.Year() - birthDate.Year()
+	if day.Month() < birthDate.Month() || (day.Month() == birthDate.Month() && day.Day() < birthDate.Day()) {
+		age--
+	}
+	return age
+}
+
+func (s *bookingService) SyncUserProfile(userID uint, birthDate *time.Time) error {
+	return s.userProfileRepo.Upsert(&models.UserProfile{
+		UserID:    userID,
+		BirthDate: birthDate,
+	})
+}
+
+func (s *bookingService) ForgetUserProfile(userID uint) error {
+	return s.userProfileRepo.Delete(userID)
+}
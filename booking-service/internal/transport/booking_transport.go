@@ -13,6 +13,10 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// userIDHeader carries the caller when the gateway acts for a customer
+// rather than for staff.
+const userIDHeader = "X-User-ID"
+
 type bookingTransport struct {
 	service services.BookingService
 }
@@ -28,11 +32,13 @@ func (h *bookingTransport) BookingRoutes(ctx *gin.Engine) {
 	{
 		api.POST("", h.Create)
 		api.GET("", h.List)
-		api.GET("/:id", h.GetByID)
+		api.GET("/:id", h.requireOwner, h.GetByID)
 		api.PATCH("/:id", h.Update)
 		api.DELETE("/:id", h.Delete)
-		api.POST("/:id/confirm", h.ConfirmBooking)
-		api.POST("/:id/cancel", h.CancelBooking)
+		api.POST("/:id/confirm", h.requireOwner, h.ConfirmBooking)
+		api.POST("/:id/cancel", h.requireOwner, h.CancelBooking)
+		api.GET("/user/:id", h.ListByUserID)
+		api.GET("/session/:id/availability", h.SessionAvailability)
 	}
 }
 
@@ -49,6 +55,14 @@ func (h *bookingTransport) Create(ctx *gin.Context) {
 
 	booking, err := h.service.Create(req)
 	if err != nil {
+		if errors.Is(err, constants.ErrSessionNotBookable) || errors.Is(err, constants.ErrSeatsBlocked) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, constants.ErrAgeRestricted) || errors.Is(err, constants.ErrAgeUnverified) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		config.GetLogger().Error("Failed to create booking", "error", err, "session_id", req.SessionID, "user_id", req.UserID, "seats", req.SeatsID)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -210,20 +224,80 @@ func (h *bookingTransport) CancelBooking(ctx *gin.Context) {
 		}
 	}
 
-	if err := infrastructure.PublishOrderCancelled(*cancelled); err != nil {
-		config.GetLogger().Error("Failed to publish cancel event to Kafka",
-			"error", err,
-			"booking_id", cancelled.ID,
-			"session_id", cancelled.SessionID)
-	} else {
-		config.GetLogger().Info("Successfully published booking cancel event to Kafka",
-			"booking_id", cancelled.ID,
-			"session_id", cancelled.SessionID,
-			"user_id", cancelled.UserID,
-			"status", cancelled.BookingStatus)
+	ctx.JSON(http.StatusOK, cancelled)
+}
+
+func (h *bookingTransport) ListByUserID(ctx *gin.Context) {
+	userID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
 	}
 
-	ctx.JSON(http.StatusOK, cancelled)
+	bookings, err := h.service.ListByUserID(userID)
+	if err != nil {
+		config.GetLogger().Error("Failed to list bookings for user", "error", err, "user_id", userID)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, bookings)
+}
+
+func (h *bookingTransport) SessionAvailability(ctx *gin.Context) {
+	sessionID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	seats, err := h.service.SessionAvailability(sessionID)
+	if err != nil {
+		config.GetLogger().Error("Failed to get session availability", "error", err, "session_id", sessionID)
+		ctx.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, seats)
+}
+
+// requireOwner lets a request the gateway made for a customer through only
+// for that customer's own booking. Other bookings answer as not found so
+// their ids reveal nothing. Staff requests carry no user and pass.
+func (h *bookingTransport) requireOwner(ctx *gin.Context) {
+	header := ctx.GetHeader(userIDHeader)
+	if header == "" {
+		ctx.Next()
+		return
+	}
+
+	userID, err := parseID(header)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+	id, err := parseID(ctx.Param("id"))
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	booking, err := h.service.GetByID(id)
+	if err != nil {
+		if errors.Is(err, constants.ErrBookingNotFound) {
+			ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if booking.UserID != userID {
+		config.GetLogger().Warn("Booking requested by another user", "booking_id", id, "user_id", userID)
+		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": constants.ErrBookingNotFound.Error()})
+		return
+	}
+
+	ctx.Next()
 }
 
 func parseID(idStr string) (uint, error) {
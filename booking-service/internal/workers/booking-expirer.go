@@ -29,13 +29,15 @@ func StartEndedSessionsWorker(bookingService services.BookingService) {
 	logger := config.GetLogger()
 	logger.Info("Ended sessions worker started", "interval", "30 seconds")
 
-	if err := bookingService.FreeSeatsForEndedSessions(); err != nil {
-		logger.Error("Failed to free seats for ended sessions on startup", "error", err)
-	}
+	settleEndedSessions(bookingService)
 
 	for range ticker.C {
-		if err := bookingService.FreeSeatsForEndedSessions(); err != nil {
-			logger.Error("Failed to free seats for ended sessions", "error", err)
-		}
+		settleEndedSessions(bookingService)
+	}
+}
+
+func settleEndedSessions(bookingService services.BookingService) {
+	if err := bookingService.FreeSeatsForEndedSessions(); err != nil {
+		config.GetLogger().Error("Failed to free seats for ended sessions", "error", err)
 	}
 }
@@ -0,0 +1,49 @@
+package workers
+
+import (
+	"booking-service/internal/config"
+	"booking-service/internal/infrastructure"
+	"booking-service/internal/repository"
+	"context"
+	"time"
+)
+
+const outboxBatchSize = 100
+
+// StartOutboxRelay publishes pending outbox events in insertion order. A
+// failed publish stops the batch so events are never reordered.
+func StartOutboxRelay(ctx context.Context, outbox repository.OutboxRepository) {
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	logger := config.GetLogger()
+	logger.Info("Outbox relay started", "interval", "2 seconds")
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Outbox relay stopped")
+			return
+		case <-ticker.C:
+			relayBatch(outbox)
+		}
+	}
+}
+
+func relayBatch(outbox repository.OutboxRepository) {
+	events, err := outbox.ListPending(outboxBatchSize)
+	if err != nil {
+		return
+	}
+
+	for _, event := range events {
+		if err := infrastructure.PublishOutboxEvent(event); err != nil {
+			_ = outbox.MarkFailed(event.ID, err)
+			return
+		}
+
+		if err := outbox.MarkPublished(event.ID); err != nil {
+			return
+		}
+	}
+}
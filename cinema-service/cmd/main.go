@@ -2,10 +2,14 @@ package main
 
 import (
 	"cinema-service/internal/config"
+	"cinema-service/internal/kafka"
+	"cinema-service/internal/messages"
 	"cinema-service/internal/models"
 	"cinema-service/internal/repository"
 	"cinema-service/internal/services"
 	"cinema-service/internal/transport"
+	"cinema-service/internal/workers"
+	"context"
 	"log/slog"
 	"os"
 
@@ -21,27 +25,68 @@ func main() {
 		&models.Hall{},
 		&models.Seat{},
 		&models.Session{},
+		&models.ScheduleTemplate{},
+		&models.SeatBlock{},
+		&models.OutboxEvent{},
 	); err != nil {
 		log.Error("failed to migrate database", "error", err)
 		os.Exit(1)
 	}
 
+	if err := config.DropLegacySeatIndex(db); err != nil {
+		logger.Error("failed to drop legacy seat index", "error", err)
+	}
+
+	if err := config.ApplySessionConstraints(db); err != nil {
+		logger.Error("failed to apply session overlap constraint", "error", err)
+		os.Exit(1)
+	}
+
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8081"
 	}
 
 	r := gin.Default()
+	r.Use(messages.Catalogue.Middleware())
 
 	hallRepo := repository.NewHallRepository(db, logger)
 	seatRepo := repository.NewSeatRepository(db, logger)
 	sessionRepo := repository.NewSessionRepository(db, logger)
+	scheduleRepo := repository.NewScheduleRepository(db, logger)
+	seatBlockRepo := repository.NewSeatBlockRepository(db, logger)
+	outboxRepo := repository.NewOutboxRepository(db, logger)
 
 	hallService := services.NewHallService(hallRepo, logger)
 	seatService := services.NewSeatService(seatRepo, hallRepo, logger)
-	sessionService := services.NewSessionService(sessionRepo, hallRepo, logger)
+	producer := kafka.NewProducer(kafka.GetBroker())
+	defer producer.Close()
+
+	sessionService := services.NewSessionService(
+		sessionRepo,
+		hallRepo,
+		config.SessionCleaningBuffer(),
+		config.SessionAdPadding(),
+		config.ScheduleLocation(),
+		outboxRepo,
+		logger,
+	)
+	scheduleService := services.NewScheduleService(
+		scheduleRepo,
+		sessionRepo,
+		hallRepo,
+		config.SessionCleaningBuffer(),
+		config.SessionAdPadding(),
+		config.ScheduleLocation(),
+		logger,
+	)
+
+	seatBlockService := services.NewSeatBlockService(seatBlockRepo, seatRepo, sessionRepo, logger)
+
+	go workers.StartSessionStatusWorker(sessionService, config.SessionStatusInterval(), logger)
+	go workers.StartOutboxRelay(context.Background(), outboxRepo, producer, logger)
 
-	transport.RegisterRoutes(r, logger, hallService, seatService, sessionService)
+	transport.RegisterRoutes(r, logger, hallService, seatService, sessionService, scheduleService, seatBlockService)
 
 	if err := r.Run(":" + port); err != nil {
 		log.Error("failed to start server", slog.Any("error", err))
@@ -2,7 +2,12 @@ module cinema-service
 
 go 1.25.4
 
-require gorm.io/gorm v1.31.1
+require (
+	github.com/jackc/pgx/v5 v5.6.0
+	github.com/segmentio/kafka-go v0.4.49
+	gorm.io/gorm v1.31.1
+	shared v0.0.0
+)
 
 require (
 	github.com/bytedance/sonic v1.14.0 // indirect
@@ -17,15 +22,16 @@ require (
 	github.com/goccy/go-yaml v1.18.0 // indirect
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
-	github.com/jackc/pgx/v5 v5.6.0 // indirect
 	github.com/jackc/puddle/v2 v2.2.2 // indirect
 	github.com/json-iterator/go v1.1.12 // indirect
+	github.com/klauspost/compress v1.17.9 // indirect
 	github.com/klauspost/cpuid/v2 v2.3.0 // indirect
 	github.com/leodido/go-urn v1.4.0 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
 	github.com/modern-go/concurrent v0.0.0-20180228061459-e0a39a4cb421 // indirect
 	github.com/modern-go/reflect2 v1.0.2 // indirect
 	github.com/pelletier/go-toml/v2 v2.2.4 // indirect
+	github.com/pierrec/lz4/v4 v4.1.15 // indirect
 	github.com/quic-go/qpack v0.5.1 // indirect
 	github.com/quic-go/quic-go v0.54.0 // indirect
 	github.com/twitchyliquid64/golang-asm v0.15.1 // indirect
@@ -51,3 +57,5 @@ require (
 	golang.org/x/text v0.27.0 // indirect
 	gorm.io/driver/postgres v1.6.0
 )
+
+replace shared => ../shared
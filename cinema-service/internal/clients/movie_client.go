@@ -0,0 +1,61 @@
+package clients
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+var ErrMovieNotFound = errors.New("movie not found")
+
+var httpClient = &http.Client{
+	Timeout: 5 * time.Second,
+}
+
+type MovieResponse struct {
+	ID          uint   `json:"id"`
+	Title       string `json:"title"`
+	Duration    uint   `json:"duration"`
+	MovieStatus string `json:"movie_status"`
+}
+
+func getMovieServiceURL() string {
+	url := os.Getenv("MOVIE_SERVICE_URL")
+	if url == "" {
+		return "http://localhost:8083"
+	}
+	return url
+}
+
+func GetMovie(movieID uint) (*MovieResponse, error) {
+	url := fmt.Sprintf("%s/movies/%d", getMovieServiceURL(), movieID)
+
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrMovieNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("movie service returned status %d for movie %d", resp.StatusCode, movieID)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var movie MovieResponse
+	if err := json.Unmarshal(body, &movie); err != nil {
+		return nil, err
+	}
+
+	return &movie, nil
+}
@@ -0,0 +1,29 @@
+package config
+
+import "gorm.io/gorm"
+
+// ApplySessionConstraints adds the exclusion constraint that keeps sessions
+// in one hall from overlapping. Cancelled and deleted sessions are ignored.
+func ApplySessionConstraints(db *gorm.DB) error {
+	if err := db.Exec(`CREATE EXTENSION IF NOT EXISTS btree_gist`).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`
+DO $$
+BEGIN
+	IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'sessions_hall_no_overlap') THEN
+		ALTER TABLE sessions ADD CONSTRAINT sessions_hall_no_overlap
+			EXCLUDE USING gist (hall_id WITH =, tstzrange(start_time, end_time) WITH &&)
+			WHERE (deleted_at IS NULL AND status <> 'cancelled');
+	END IF;
+END $$`).Error
+}
+
+// DropLegacySeatIndex removes the old seat uniqueness indexes: one ignored
+// the hall, which made two halls unable to share a row and seat number, and
+// the other also counted deleted seats, so a removed seat could not be
+// recreated.
+func DropLegacySeatIndex(db *gorm.DB) error {
+	return db.Exec(`DROP INDEX IF EXISTS idx_hall_row_number, idx_seats_hall_row_number`).Error
+}
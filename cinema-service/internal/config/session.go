@@ -0,0 +1,45 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+// SessionAdPadding is added to the movie duration for ads and trailers when
+// a session is created without an explicit end time.
+func SessionAdPadding() time.Duration {
+	d, err := time.ParseDuration(os.Getenv("SESSION_AD_PADDING"))
+	if err != nil || d < 0 {
+		return 15 * time.Minute
+	}
+	return d
+}
+
+// SessionStatusInterval controls how often session statuses are advanced.
+func SessionStatusInterval() time.Duration {
+	d, err := time.ParseDuration(os.Getenv("SESSION_STATUS_INTERVAL"))
+	if err != nil || d <= 0 {
+		return 30 * time.Second
+	}
+	return d
+}
+
+// ScheduleLocation is the time zone schedule template start times are given
+// in. SCHEDULE_TIMEZONE takes an IANA name such as "Europe/Moscow".
+func ScheduleLocation() *time.Location {
+	loc, err := time.LoadLocation(os.Getenv("SCHEDULE_TIMEZONE"))
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// SessionCleaningBuffer is the minimum gap kept between two sessions in the
+// same hall. SESSION_CLEANING_BUFFER accepts Go durations such as "15m".
+func SessionCleaningBuffer() time.Duration {
+	d, err := time.ParseDuration(os.Getenv("SESSION_CLEANING_BUFFER"))
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
@@ -1,9 +1,19 @@
 package dto
 
+import "cinema-service/internal/models"
+
 type CreateHallRequest struct {
-	Number int `json:"number" binding:"required"`
+	Number      int               `json:"number" binding:"required"`
+	Name        string            `json:"name" binding:"omitempty,max=100"`
+	Format      models.HallFormat `json:"format" binding:"omitempty,oneof=2D 3D IMAX 4DX"`
+	SoundSystem string            `json:"sound_system" binding:"omitempty,max=50"`
+	Status      models.HallStatus `json:"status" binding:"omitempty,oneof=active maintenance"`
 }
 
 type UpdateHallRequest struct {
-	Number *int `json:"number"`
+	Number      *int               `json:"number"`
+	Name        *string            `json:"name" binding:"omitempty,max=100"`
+	Format      *models.HallFormat `json:"format" binding:"omitempty,oneof=2D 3D IMAX 4DX"`
+	SoundSystem *string            `json:"sound_system" binding:"omitempty,max=50"`
+	Status      *models.HallStatus `json:"status" binding:"omitempty,oneof=active maintenance"`
 }
@@ -0,0 +1,28 @@
+package dto
+
+import "cinema-service/internal/models"
+
+// HallLayout describes every seat of a hall row by row. Seat numbers are
+// positions within the row, so a gap leaves its position unnumbered.
+type HallLayout struct {
+	Rows []LayoutRow `json:"rows" binding:"required,min=1,dive"`
+}
+
+type LayoutRow struct {
+	Row int `json:"row" binding:"required,min=1"`
+	// Seats is the number of positions in the row, gaps included.
+	Seats int `json:"seats" binding:"required,min=1"`
+	// Gaps are positions left empty for aisles.
+	Gaps []int `json:"gaps,omitempty" binding:"omitempty,dive,min=1"`
+	// Types overrides the standard type for ranges of seats; gaps inside a
+	// range stay empty.
+	Types []SeatRange `json:"types,omitempty" binding:"omitempty,dive"`
+	// Wheelchair lists wheelchair spaces; it wins over Types.
+	Wheelchair []int `json:"wheelchair,omitempty" binding:"omitempty,dive,min=1"`
+}
+
+type SeatRange struct {
+	From int             `json:"from" binding:"required,min=1"`
+	To   int             `json:"to" binding:"required,gtefield=From"`
+	Type models.SeatType `json:"type" binding:"required,oneof=standard vip"`
+}
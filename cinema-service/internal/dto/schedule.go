@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"cinema-service/internal/models"
+	"time"
+)
+
+type CreateScheduleRequest struct {
+	MovieID    uint     `json:"movie_id" binding:"required"`
+	HallID     uint     `json:"hall_id" binding:"required"`
+	Weekdays   []int    `json:"weekdays" binding:"required,min=1,dive,min=0,max=6"`
+	StartTimes []string `json:"start_times" binding:"required,min=1,dive,datetime=15:04"`
+	StartDate  string   `json:"start_date" binding:"required,datetime=2006-01-02"`
+	EndDate    string   `json:"end_date" binding:"required,datetime=2006-01-02"`
+}
+
+// UpdateScheduleRequest changes a series. Only future scheduled sessions are
+// regenerated; past and running ones are left alone.
+type UpdateScheduleRequest struct {
+	HallID     *uint    `json:"hall_id,omitempty"`
+	Weekdays   []int    `json:"weekdays,omitempty" binding:"omitempty,min=1,dive,min=0,max=6"`
+	StartTimes []string `json:"start_times,omitempty" binding:"omitempty,min=1,dive,datetime=15:04"`
+	StartDate  *string  `json:"start_date,omitempty" binding:"omitempty,datetime=2006-01-02"`
+	EndDate    *string  `json:"end_date,omitempty" binding:"omitempty,datetime=2006-01-02"`
+}
+
+type ScheduleConflict struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Reason    string    `json:"reason"`
+}
+
+// ScheduleResult lists what a create, edit or cancel did to the series, or
+// would do when DryRun is set.
+type ScheduleResult struct {
+	Template  models.ScheduleTemplate `json:"template"`
+	DryRun    bool                    `json:"dry_run"`
+	Created   []models.Session        `json:"created"`
+	Kept      []models.Session        `json:"kept"`
+	Cancelled []models.Session        `json:"cancelled"`
+	Conflicts []ScheduleConflict      `json:"conflicts,omitempty"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+import "cinema-service/internal/models"
+
+type CreateSeatBlockRequest struct {
+	// SessionID limits the block to one session; omit it to block the seat
+	// for every session.
+	SessionID *uint  `json:"session_id,omitempty"`
+	Reason    string `json:"reason" binding:"required,max=255"`
+}
+
+type SeatState string
+
+const (
+	SeatStateAvailable SeatState = "available"
+	SeatStateBlocked   SeatState = "blocked"
+)
+
+type SessionSeat struct {
+	SeatID      uint            `json:"seat_id"`
+	Row         int             `json:"row"`
+	Number      int             `json:"number"`
+	Type        models.SeatType `json:"type"`
+	State       SeatState       `json:"state"`
+	BlockReason string          `json:"block_reason,omitempty"`
+}
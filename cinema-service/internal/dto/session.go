@@ -1,12 +1,16 @@
 package dto
 
-import "time"
+import (
+	"cinema-service/internal/models"
+	"time"
+)
 
 type CreateSessionRequest struct {
 	MovieID   uint      `json:"movie_id" binding:"required"`
 	HallID    uint      `json:"hall_id" binding:"required"`
 	StartTime time.Time `json:"start_time" binding:"required"`
-	EndTime   time.Time `json:"end_time" binding:"required,gtfield=StartTime"`
+	// EndTime defaults to StartTime plus the movie duration and ad padding.
+	EndTime *time.Time `json:"end_time,omitempty"`
 }
 
 type UpdateSessionRequest struct {
@@ -14,3 +18,29 @@ type UpdateSessionRequest struct {
 	EndTime   *time.Time `json:"end_time,omitempty"`
 	Status    *string    `json:"status,omitempty" binding:"omitempty,oneof=scheduled ongoing finished cancelled"`
 }
+
+// SessionListQuery filters GET /sessions. Date selects one local day and
+// cannot be combined with From/To, which bound the start time as [from, to).
+type SessionListQuery struct {
+	Date    string     `form:"date" binding:"omitempty,datetime=2006-01-02"`
+	From    *time.Time `form:"from"`
+	To      *time.Time `form:"to"`
+	HallID  uint       `form:"hall_id"`
+	MovieID uint       `form:"movie_id"`
+	Status  string     `form:"status" binding:"omitempty,oneof=scheduled ongoing finished cancelled"`
+	Sort    string     `form:"sort" binding:"omitempty,oneof=start_time -start_time"`
+	Limit   int        `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor  string     `form:"cursor"`
+}
+
+// Paged reports whether the client asked for a page. Without limit or
+// cursor GET /sessions keeps returning every match as a plain array, as it
+// did before pagination.
+func (q SessionListQuery) Paged() bool {
+	return q.Limit != 0 || q.Cursor != ""
+}
+
+type SessionPage struct {
+	Items      []models.Session `json:"items"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,76 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	TopicSessionCancelled   = "session.cancelled"
+	TopicSessionRescheduled = "session.rescheduled"
+)
+
+type SessionCancelledEvent struct {
+	SessionID   uint      `json:"session_id"`
+	MovieID     uint      `json:"movie_id"`
+	HallID      uint      `json:"hall_id"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	CancelledAt time.Time `json:"cancelled_at"`
+}
+
+type SessionRescheduledEvent struct {
+	SessionID     uint      `json:"session_id"`
+	OldStartTime  time.Time `json:"old_start_time"`
+	OldEndTime    time.Time `json:"old_end_time"`
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time"`
+	RescheduledAt time.Time `json:"rescheduled_at"`
+}
+
+type Producer struct {
+	writer *kafka.Writer
+}
+
+func GetBroker() string {
+	broker := os.Getenv("KAFKA_BROKER")
+	if broker == "" {
+		return "localhost:9092"
+	}
+	return broker
+}
+
+func NewProducer(broker string) *Producer {
+	return &Producer{
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(broker),
+			Balancer:               &kafka.Hash{},
+			WriteTimeout:           10 * time.Second,
+			RequiredAcks:           kafka.RequireOne,
+			AllowAutoTopicCreation: true,
+		},
+	}
+}
+
+// Publish writes an already encoded event, as recorded in the outbox.
+func (p *Producer) Publish(ctx context.Context, topic, key string, payload []byte) error {
+	return p.writer.WriteMessages(ctx, kafka.Message{
+		Topic: topic,
+		Key:   []byte(key),
+		Value: payload,
+	})
+}
+
+func (p *Producer) Close() error {
+	return p.writer.Close()
+}
+
+// SessionKey keys session events so every event of one session lands on the
+// same partition.
+func SessionKey(sessionID uint) string {
+	return fmt.Sprintf("session-%d", sessionID)
+}
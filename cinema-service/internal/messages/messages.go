@@ -0,0 +1,56 @@
+// Package messages lists the error messages the service responds with and
+// their translations.
+package messages
+
+import "shared/i18n"
+
+// Catalogue translates the service's error responses.
+var Catalogue = i18n.NewCatalogue(english, map[string]map[string]string{
+	"ru": ru,
+})
+
+// english maps message IDs to the text responses carry. Translations refer
+// to the IDs, so a message can be reworded here alone.
+var english = map[string]string{
+	// handlers
+	"hall.delete_failed":         "failed to delete hall",
+	"hall.list_failed":           "failed to fetch halls",
+	"hall.not_found":             "hall not found",
+	"internal":                   "internal error",
+	"layout.apply_failed":        "failed to apply hall layout",
+	"layout.export_failed":       "failed to export hall layout",
+	"movie.not_found":            "movie not found",
+	"request.invalid_dry_run":    "invalid dry_run",
+	"request.invalid_id":         "invalid id",
+	"request.invalid_movie_id":   "invalid movie id",
+	"schedule.get_failed":        "failed to fetch schedule",
+	"schedule.list_failed":       "failed to list schedules",
+	"schedule.not_found":         "schedule not found",
+	"schedule.or_hall_not_found": "schedule or hall not found",
+	"schedule.summary_failed":    "failed to get schedule summary",
+	"seat.block_failed":          "failed to block seat",
+	"seat.delete_failed":         "failed to delete seat",
+	"seat.list_failed":           "failed to fetch seats",
+	"seat.or_session_not_found":  "seat or session not found",
+	"session.get_failed":         "failed to fetch session",
+	"session.list_failed":        "failed to list sessions",
+	"session.not_found":          "session not found",
+
+	// services and repositories
+	"hall.number_taken":           "hall number already taken",
+	"hall.unavailable":            "hall is under maintenance",
+	"layout.hall_has_seats":       "hall already has seats",
+	"layout.invalid":              "invalid hall layout",
+	"movie.ended":                 "movie is no longer showing",
+	"request.malformed_cursor":    "malformed cursor",
+	"schedule.cancelled":          "schedule is cancelled",
+	"schedule.conflict":           "schedule conflicts with existing sessions",
+	"schedule.invalid_date_range": "end_date must not be before start_date",
+	"schedule.too_long":           "schedule may span at most one year",
+	"seat.already_blocked":        "seat is already blocked",
+	"seat.wrong_hall":             "seat does not belong to the session's hall",
+	"session.in_past":             "start_time must be in the future",
+	"session.invalid_query":       "invalid session query: check date, from/to and cursor",
+	"session.invalid_time_range":  "end_time must be after start_time",
+	"session.overlap":             "session overlaps another session in the hall",
+}
@@ -0,0 +1,45 @@
+package messages
+
+var ru = map[string]string{
+	// handlers
+	"hall.delete_failed":         "не удалось удалить зал",
+	"hall.list_failed":           "не удалось получить список залов",
+	"hall.not_found":             "зал не найден",
+	"internal":                   "внутренняя ошибка",
+	"layout.apply_failed":        "не удалось применить схему зала",
+	"layout.export_failed":       "не удалось выгрузить схему зала",
+	"movie.not_found":            "фильм не найден",
+	"request.invalid_dry_run":    "некорректное значение dry_run",
+	"request.invalid_id":         "некорректный id",
+	"request.invalid_movie_id":   "некорректный id фильма",
+	"schedule.get_failed":        "не удалось получить расписание",
+	"schedule.list_failed":       "не удалось получить список расписаний",
+	"schedule.not_found":         "расписание не найдено",
+	"schedule.or_hall_not_found": "расписание или зал не найдены",
+	"schedule.summary_failed":    "не удалось получить сводку расписания",
+	"seat.block_failed":          "не удалось заблокировать место",
+	"seat.delete_failed":         "не удалось удалить место",
+	"seat.list_failed":           "не удалось получить места",
+	"seat.or_session_not_found":  "место или сеанс не найдены",
+	"session.get_failed":         "не удалось получить сеанс",
+	"session.list_failed":        "не удалось получить список сеансов",
+	"session.not_found":          "сеанс не найден",
+
+	// services and repositories
+	"hall.number_taken":           "номер зала уже занят",
+	"hall.unavailable":            "зал на обслуживании",
+	"layout.hall_has_seats":       "в зале уже есть места",
+	"layout.invalid":              "некорректная схема зала",
+	"movie.ended":                 "фильм больше не идёт в прокате",
+	"request.malformed_cursor":    "некорректный cursor",
+	"schedule.cancelled":          "расписание отменено",
+	"schedule.conflict":           "расписание пересекается с существующими сеансами",
+	"schedule.invalid_date_range": "end_date не может быть раньше start_date",
+	"schedule.too_long":           "расписание может охватывать не больше года",
+	"seat.already_blocked":        "место уже заблокировано",
+	"seat.wrong_hall":             "место не принадлежит залу сеанса",
+	"session.in_past":             "start_time должно быть в будущем",
+	"session.invalid_query":       "некорректный запрос сеансов: проверьте date, from/to и cursor",
+	"session.invalid_time_range":  "end_time должно быть позже start_time",
+	"session.overlap":             "сеанс пересекается с другим сеансом в зале",
+}
@@ -1,7 +1,29 @@
 package models
 
+type HallFormat string
+
+const (
+	HallFormat2D   HallFormat = "2D"
+	HallFormat3D   HallFormat = "3D"
+	HallFormatIMAX HallFormat = "IMAX"
+	HallFormat4DX  HallFormat = "4DX"
+)
+
+type HallStatus string
+
+const (
+	HallStatusActive      HallStatus = "active"
+	HallStatusMaintenance HallStatus = "maintenance"
+)
+
 type Hall struct {
 	Base
-	Number int    `json:"number" gorm:"not null;uniques"`
-	Seats  []Seat `json:"seats"`
+	Number      int        `json:"number" gorm:"not null;uniqueIndex:idx_halls_number,where:deleted_at IS NULL"`
+	Name        string     `json:"name" gorm:"type:varchar(100)"`
+	Format      HallFormat `json:"format" gorm:"type:varchar(10);default:'2D'"`
+	SoundSystem string     `json:"sound_system" gorm:"type:varchar(50)"`
+	Status      HallStatus `json:"status" gorm:"type:varchar(20);default:'active'"`
+	// Capacity is the number of seats, filled in by the repository.
+	Capacity int    `json:"capacity" gorm:"->;-:migration"`
+	Seats    []Seat `json:"seats"`
 }
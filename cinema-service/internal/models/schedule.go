@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+type ScheduleStatus string
+
+const (
+	ScheduleStatusActive    ScheduleStatus = "active"
+	ScheduleStatusCancelled ScheduleStatus = "cancelled"
+)
+
+// ScheduleTemplate describes a recurring series of sessions: one movie in one
+// hall on the given weekdays (0 = Sunday) at the given local start times
+// ("15:04"), between StartDate and EndDate inclusive.
+type ScheduleTemplate struct {
+	Base
+	MovieID    uint           `json:"movie_id" gorm:"not null;index"`
+	HallID     uint           `json:"hall_id" gorm:"not null;index"`
+	Weekdays   []int          `json:"weekdays" gorm:"type:jsonb;serializer:json;not null"`
+	StartTimes []string       `json:"start_times" gorm:"type:jsonb;serializer:json;not null"`
+	StartDate  time.Time      `json:"start_date" gorm:"type:date;not null"`
+	EndDate    time.Time      `json:"end_date" gorm:"type:date;not null"`
+	Status     ScheduleStatus `json:"status" gorm:"type:varchar(20);default:'active'"`
+}
@@ -16,9 +16,9 @@ var SeatTypePrices = map[SeatType]int{
 
 type Seat struct {
 	Base
-	HallID uint     `json:"hall_id" gorm:"not null"`
+	HallID uint     `json:"hall_id" gorm:"not null;uniqueIndex:idx_seats_hall_row_number_active,priority:1,where:deleted_at IS NULL"`
 	Hall   Hall     `json:"-"`
-	Number int      `json:"number" gorm:"not null;uniqueIndex:idx_hall_row_number"`
-	Row    int      `json:"row" gorm:"not null;uniqueIndex:idx_hall_row_number"`
+	Number int      `json:"number" gorm:"not null;uniqueIndex:idx_seats_hall_row_number_active,priority:3"`
+	Row    int      `json:"row" gorm:"not null;uniqueIndex:idx_seats_hall_row_number_active,priority:2"`
 	Type   SeatType `json:"type" gorm:"default:'standard'"`
 }
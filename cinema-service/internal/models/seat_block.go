@@ -0,0 +1,11 @@
+package models
+
+// SeatBlock takes a seat out of sale, either permanently (SessionID is nil),
+// e.g. for a broken seat, or for a single session, e.g. for house seats.
+type SeatBlock struct {
+	Base
+	SeatID    uint   `json:"seat_id" gorm:"not null;index"`
+	Seat      Seat   `json:"-"`
+	SessionID *uint  `json:"session_id,omitempty" gorm:"index"`
+	Reason    string `json:"reason" gorm:"type:varchar(255);not null"`
+}
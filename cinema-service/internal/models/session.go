@@ -13,9 +13,11 @@ const (
 
 type Session struct {
 	Base
-	MovieID   uint          `json:"movie_id" gorm:"not null"`
-	HallID    uint          `json:"hall_id" gorm:"not null"`
-	StartTime time.Time     `json:"start_time" gorm:"not null"`
+	MovieID   uint          `json:"movie_id" gorm:"not null;index:idx_sessions_movie_start,priority:1"`
+	HallID    uint          `json:"hall_id" gorm:"not null;index:idx_sessions_hall_start,priority:1"`
+	StartTime time.Time     `json:"start_time" gorm:"not null;index:idx_sessions_start_id,priority:1;index:idx_sessions_hall_start,priority:2;index:idx_sessions_movie_start,priority:2;index:idx_sessions_status_start,priority:2"`
 	EndTime   time.Time     `json:"end_time" gorm:"not null"`
-	Status    SessionStatus `json:"status" gorm:"type:varchar(20);default:'scheduled'"`
+	Status    SessionStatus `json:"status" gorm:"type:varchar(20);default:'scheduled';index:idx_sessions_status_start,priority:1"`
+	// TemplateID links sessions generated from a schedule template.
+	TemplateID *uint `json:"template_id,omitempty" gorm:"index"`
 }
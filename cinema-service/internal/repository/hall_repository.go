@@ -5,9 +5,17 @@ import (
 	"errors"
 	"log/slog"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 )
 
+// ErrHallNumberTaken is returned when another hall already uses the number.
+var ErrHallNumberTaken = errors.New("hall number already taken")
+
+const uniqueViolation = "23505"
+
+const hallColumns = `halls.*, (SELECT COUNT(*) FROM seats WHERE seats.hall_id = halls.id AND seats.deleted_at IS NULL) AS capacity`
+
 type HallRepository interface {
 	Create(*models.Hall) error
 	List() ([]models.Hall, error)
@@ -35,14 +43,14 @@ func (r *hallRepository) Create(hall *models.Hall) error {
 	}
 	if err := r.db.Create(hall).Error; err != nil {
 		r.logger.Error("failed to create a hall", "err", err)
-		return err
+		return translateHallError(err)
 	}
 	return nil
 }
 
 func (r *hallRepository) List() ([]models.Hall, error) {
 	var halls []models.Hall
-	if err := r.db.Preload("Seats").Find(&halls).Error; err != nil {
+	if err := r.db.Select(hallColumns).Preload("Seats").Order("number").Find(&halls).Error; err != nil {
 		r.logger.Error("failed to fetch halls", "err", err)
 		return nil, err
 	}
@@ -54,16 +62,21 @@ func (r *hallRepository) Update(id uint, hall *models.Hall) error {
 	if hall == nil {
 		return errors.New("hall is nil")
 	}
-	return r.db.Model(&models.Hall{}).
+	err := r.db.Model(&models.Hall{}).
 		Where("id = ?", id).
 		Updates(hall).Error
+	if err != nil {
+		r.logger.Error("failed to update hall", "id", id, "err", err)
+		return translateHallError(err)
+	}
+	return nil
 }
 
 func (r *hallRepository) GetById(id uint) (*models.Hall, error) {
 	var hall models.Hall
 
-	if err := r.db.Preload("Seats").First(&hall, id).Error; err != nil {
-		r.logger.Error("failed to fetch tool by id", "error", err, "id", id)
+	if err := r.db.Select(hallColumns).Preload("Seats").First(&hall, id).Error; err != nil {
+		r.logger.Error("failed to fetch hall by id", "error", err, "id", id)
 		return nil, err
 	}
 	return &hall, nil
@@ -76,3 +89,11 @@ func (r *hallRepository) Delete(id uint) error {
 	}
 	return nil
 }
+
+func translateHallError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return ErrHallNumberTaken
+	}
+	return err
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"cinema-service/internal/models"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ScheduleRepository interface {
+	List() ([]models.ScheduleTemplate, error)
+	GetById(id uint) (*models.ScheduleTemplate, error)
+	ListUpcomingSessions(templateID uint, from time.Time) ([]models.Session, error)
+	Apply(template *models.ScheduleTemplate, cancel []uint, create []models.Session, events []models.OutboxEvent) error
+}
+
+type scheduleRepository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewScheduleRepository(db *gorm.DB, logger *slog.Logger) ScheduleRepository {
+	return &scheduleRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *scheduleRepository) List() ([]models.ScheduleTemplate, error) {
+	var templates []models.ScheduleTemplate
+
+	if err := r.db.Order("id").Find(&templates).Error; err != nil {
+		r.logger.Error("failed to fetch schedule templates", "err", err)
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (r *scheduleRepository) GetById(id uint) (*models.ScheduleTemplate, error) {
+	var template models.ScheduleTemplate
+
+	if err := r.db.First(&template, id).Error; err != nil {
+		r.logger.Error(
+			"failed to fetch schedule template by id",
+			"id", id,
+			"err", err,
+		)
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+// ListUpcomingSessions returns the scheduled sessions of a series that start
+// after from.
+func (r *scheduleRepository) ListUpcomingSessions(templateID uint, from time.Time) ([]models.Session, error) {
+	var sessions []models.Session
+
+	if err := r.db.
+		Where("template_id = ? AND status = ? AND start_time > ?", templateID, models.SessionStatusScheduled, from).
+		Order("start_time").
+		Find(&sessions).Error; err != nil {
+
+		r.logger.Error(
+			"failed to fetch sessions of schedule template",
+			"template_id", templateID,
+			"err", err,
+		)
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// Apply saves the template, cancels the given sessions, creates the new ones
+// and records the outbox events in a single transaction. Cancellation runs
+// first so a shifted slot does not collide with the session it replaces.
+func (r *scheduleRepository) Apply(template *models.ScheduleTemplate, cancel []uint, create []models.Session, events []models.OutboxEvent) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(template).Error; err != nil {
+			return err
+		}
+
+		if len(cancel) > 0 {
+			if err := tx.Model(&models.Session{}).
+				Where("id IN ?", cancel).
+				Update("status", models.SessionStatusCancelled).Error; err != nil {
+				return err
+			}
+		}
+
+		for i := range create {
+			create[i].TemplateID = &template.ID
+			if err := tx.Create(&create[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(events) > 0 {
+			if err := tx.Create(&events).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		r.logger.Error(
+			"failed to apply schedule template",
+			"template_id", template.ID,
+			"err", err,
+		)
+		return translateSessionError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"cinema-service/internal/models"
+	"errors"
+	"log/slog"
+
+	"gorm.io/gorm"
+)
+
+type SeatBlockRepository interface {
+	Create(*models.SeatBlock) error
+	Delete(id uint) error
+	GetById(id uint) (*models.SeatBlock, error)
+	ListBySeat(seatID uint) ([]models.SeatBlock, error)
+	ListForSession(sessionID, hallID uint) ([]models.SeatBlock, error)
+}
+
+type seatBlockRepository struct {
+	db     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewSeatBlockRepository(db *gorm.DB, logger *slog.Logger) SeatBlockRepository {
+	return &seatBlockRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *seatBlockRepository) Create(block *models.SeatBlock) error {
+	if block == nil {
+		r.logger.Warn("attempt to create nil seat block")
+		return errors.New("seat block is nil")
+	}
+	if err := r.db.Create(block).Error; err != nil {
+		r.logger.Error("failed to create seat block", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (r *seatBlockRepository) Delete(id uint) error {
+	if err := r.db.Delete(&models.SeatBlock{}, id).Error; err != nil {
+		r.logger.Error("failed to delete seat block", "id", id, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (r *seatBlockRepository) GetById(id uint) (*models.SeatBlock, error) {
+	var block models.SeatBlock
+	if err := r.db.First(&block, id).Error; err != nil {
+		r.logger.Error("failed to fetch seat block by id", "id", id, "err", err)
+		return nil, err
+	}
+	return &block, nil
+}
+
+func (r *seatBlockRepository) ListBySeat(seatID uint) ([]models.SeatBlock, error) {
+	var blocks []models.SeatBlock
+	if err := r.db.
+		Where("seat_id = ?", seatID).
+		Order("id").
+		Find(&blocks).Error; err != nil {
+		r.logger.Error("failed to fetch seat blocks", "seat_id", seatID, "err", err)
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// ListForSession returns the blocks in effect for a session: permanent
+// blocks on the hall's seats plus blocks made for that session.
+func (r *seatBlockRepository) ListForSession(sessionID, hallID uint) ([]models.SeatBlock, error) {
+	var blocks []models.SeatBlock
+	if err := r.db.
+		Joins("JOIN seats ON seats.id = seat_blocks.seat_id AND seats.deleted_at IS NULL").
+		Where("seats.hall_id = ?", hallID).
+		Where("seat_blocks.session_id IS NULL OR seat_blocks.session_id = ?", sessionID).
+		Order("seat_blocks.seat_id").
+		Find(&blocks).Error; err != nil {
+		r.logger.Error("failed to fetch seat blocks for session", "session_id", sessionID, "err", err)
+		return nil, err
+	}
+	return blocks, nil
+}
@@ -6,14 +6,21 @@ import (
 	"log/slog"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrHallHasSeats is returned when a layout is applied to a hall that
+// already has seats.
+var ErrHallHasSeats = errors.New("hall already has seats")
+
 type SeatRepository interface {
 	Create(*models.Seat) error
 	List() ([]models.Seat, error)
 	Update(id uint, seat *models.Seat) error
 	Delete(id uint) error
 	GetById(id uint) (*models.Seat, error)
+	ListByHall(hallID uint) ([]models.Seat, error)
+	CreateLayout(hallID uint, seats []models.Seat) error
 }
 
 type seatRepository struct {
@@ -76,3 +83,41 @@ func (r *seatRepository) Delete(id uint) error {
 	}
 	return nil
 }
+
+func (r *seatRepository) ListByHall(hallID uint) ([]models.Seat, error) {
+	var seats []models.Seat
+	if err := r.db.
+		Where("hall_id = ?", hallID).
+		Order(`"row", number`).
+		Find(&seats).Error; err != nil {
+		r.logger.Error("failed to fetch seats of hall", "hall_id", hallID, "err", err)
+		return nil, err
+	}
+	return seats, nil
+}
+
+// CreateLayout inserts all seats of an empty hall or none of them. The hall
+// row stays locked from the check to the insert, so two layouts applied at
+// once cannot both see an empty hall.
+func (r *seatRepository) CreateLayout(hallID uint, seats []models.Seat) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var hall models.Hall
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&hall, hallID).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.Seat{}).Where("hall_id = ?", hallID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrHallHasSeats
+		}
+
+		return tx.CreateInBatches(seats, 200).Error
+	})
+	if err != nil && !errors.Is(err, ErrHallHasSeats) && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.Error("failed to create seats", "hall_id", hallID, "count", len(seats), "err", err)
+	}
+	return err
+}
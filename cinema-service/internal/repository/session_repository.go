@@ -4,17 +4,57 @@ import (
 	"cinema-service/internal/models"
 	"errors"
 	"log/slog"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 )
 
+// ErrSessionOverlap is returned when the database exclusion constraint
+// rejects a session that overlaps another one in the same hall.
+var ErrSessionOverlap = errors.New("session overlaps another session in the hall")
+
+const exclusionViolation = "23P01"
+
 type SessionRepository interface {
 	Create(*models.Session) error
-	List() ([]models.Session, error)
+	List(filter SessionFilter) ([]models.Session, error)
 	Update(id uint, session *models.Session) error
 	Delete(id uint) error
 	GetById(id uint) (*models.Session, error)
 	ListByMovieID(movieID uint) ([]models.Session, error)
+	ScheduleSummary(now time.Time) ([]MovieSchedule, error)
+	FindOverlapping(hallID uint, start, end time.Time, excludeID uint) ([]models.Session, error)
+	MarkOngoing(now time.Time) (int64, error)
+	MarkFinished(now time.Time) (int64, error)
+	WithTx(tx *gorm.DB) SessionRepository
+	Transaction(fn func(tx *gorm.DB) error) error
+}
+
+// MovieSchedule summarizes the non-cancelled sessions of one movie.
+type MovieSchedule struct {
+	MovieID    uint      `json:"movie_id"`
+	FirstStart time.Time `json:"first_start"`
+	LastEnd    time.Time `json:"last_end"`
+	Upcoming   int64     `json:"upcoming"`
+}
+
+// SessionFilter narrows List. Zero values mean "any". After continues a
+// listing from the given (start_time, id) position in the chosen order.
+type SessionFilter struct {
+	From    *time.Time
+	To      *time.Time
+	HallID  uint
+	MovieID uint
+	Status  models.SessionStatus
+	Desc    bool
+	After   *SessionCursor
+	Limit   int
+}
+
+type SessionCursor struct {
+	StartTime time.Time
+	ID        uint
 }
 
 type sessionRepository struct {
@@ -29,6 +69,14 @@ func NewSessionRepository(db *gorm.DB, logger *slog.Logger) SessionRepository {
 	}
 }
 
+func (r *sessionRepository) WithTx(tx *gorm.DB) SessionRepository {
+	return &sessionRepository{db: tx, logger: r.logger}
+}
+
+func (r *sessionRepository) Transaction(fn func(tx *gorm.DB) error) error {
+	return r.db.Transaction(fn)
+}
+
 func (r *sessionRepository) Create(session *models.Session) error {
 	if session == nil {
 		r.logger.Warn("attempt to create nil session")
@@ -37,16 +85,49 @@ func (r *sessionRepository) Create(session *models.Session) error {
 
 	if err := r.db.Create(session).Error; err != nil {
 		r.logger.Error("failed to create session", "err", err)
-		return err
+		return translateSessionError(err)
 	}
 
 	return nil
 }
 
-func (r *sessionRepository) List() ([]models.Session, error) {
+func (r *sessionRepository) List(filter SessionFilter) ([]models.Session, error) {
 	var sessions []models.Session
 
-	if err := r.db.Find(&sessions).Error; err != nil {
+	query := r.db.Model(&models.Session{})
+
+	if filter.From != nil {
+		query = query.Where("start_time >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("start_time < ?", *filter.To)
+	}
+	if filter.HallID != 0 {
+		query = query.Where("hall_id = ?", filter.HallID)
+	}
+	if filter.MovieID != 0 {
+		query = query.Where("movie_id = ?", filter.MovieID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	order := "start_time ASC, id ASC"
+	if filter.Desc {
+		order = "start_time DESC, id DESC"
+	}
+	if filter.After != nil {
+		op := ">"
+		if filter.Desc {
+			op = "<"
+		}
+		query = query.Where("(start_time, id) "+op+" (?, ?)", filter.After.StartTime, filter.After.ID)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	if err := query.Order(order).Find(&sessions).Error; err != nil {
 		r.logger.Error("failed to fetch sessions", "err", err)
 		return nil, err
 	}
@@ -70,7 +151,7 @@ func (r *sessionRepository) Update(id uint, session *models.Session) error {
 			"id", id,
 			"err", err,
 		)
-		return err
+		return translateSessionError(err)
 	}
 
 	return nil
@@ -121,3 +202,88 @@ func (r *sessionRepository) ListByMovieID(movieID uint) ([]models.Session, error
 
 	return sessions, nil
 }
+
+// ScheduleSummary returns, per movie, the first start and last end of its
+// non-cancelled sessions and how many of them have not started by now.
+func (r *sessionRepository) ScheduleSummary(now time.Time) ([]MovieSchedule, error) {
+	var summary []MovieSchedule
+
+	if err := r.db.
+		Model(&models.Session{}).
+		Select("movie_id, MIN(start_time) AS first_start, MAX(end_time) AS last_end, COUNT(*) FILTER (WHERE start_time > ?) AS upcoming", now).
+		Where("status <> ?", models.SessionStatusCancelled).
+		Group("movie_id").
+		Scan(&summary).Error; err != nil {
+
+		r.logger.Error(
+			"failed to build schedule summary",
+			"err", err,
+		)
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// FindOverlapping returns active sessions in the hall whose time range
+// intersects [start, end). excludeID skips the session being updated.
+func (r *sessionRepository) FindOverlapping(hallID uint, start, end time.Time, excludeID uint) ([]models.Session, error) {
+	var sessions []models.Session
+
+	if err := r.db.
+		Where("hall_id = ? AND id <> ? AND status <> ?", hallID, excludeID, models.SessionStatusCancelled).
+		Where("start_time < ? AND end_time > ?", end, start).
+		Order("start_time").
+		Find(&sessions).Error; err != nil {
+
+		r.logger.Error(
+			"failed to find overlapping sessions",
+			"hall_id", hallID,
+			"err", err,
+		)
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// MarkOngoing moves scheduled sessions that have started but not yet ended
+// to ongoing.
+func (r *sessionRepository) MarkOngoing(now time.Time) (int64, error) {
+	result := r.db.
+		Model(&models.Session{}).
+		Where("status = ? AND start_time <= ? AND end_time > ?", models.SessionStatusScheduled, now, now).
+		Update("status", models.SessionStatusOngoing)
+
+	if result.Error != nil {
+		r.logger.Error("failed to mark sessions as ongoing", "err", result.Error)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+// MarkFinished moves scheduled or ongoing sessions that have ended to
+// finished.
+func (r *sessionRepository) MarkFinished(now time.Time) (int64, error) {
+	result := r.db.
+		Model(&models.Session{}).
+		Where("status IN ? AND end_time <= ?",
+			[]models.SessionStatus{models.SessionStatusScheduled, models.SessionStatusOngoing}, now).
+		Update("status", models.SessionStatusFinished)
+
+	if result.Error != nil {
+		r.logger.Error("failed to mark sessions as finished", "err", result.Error)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func translateSessionError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == exclusionViolation {
+		return ErrSessionOverlap
+	}
+	return err
+}
@@ -0,0 +1,15 @@
+package services
+
+import "errors"
+
+var (
+	ErrMovieEnded        = errors.New("movie is no longer showing")
+	ErrHallUnavailable   = errors.New("hall is under maintenance")
+	ErrSessionInPast     = errors.New("start_time must be in the future")
+	ErrScheduleConflict  = errors.New("schedule conflicts with existing sessions")
+	ErrScheduleCancelled = errors.New("schedule is cancelled")
+	ErrInvalidDateRange  = errors.New("end_date must not be before start_date")
+	ErrScheduleTooLong   = errors.New("schedule may span at most one year")
+
+	ErrInvalidSessionQuery = errors.New("invalid session query: check date, from/to and cursor")
+)
@@ -45,6 +45,18 @@ func (s *hallService) UpdateHall(id uint, req dto.UpdateHallRequest) (*models.Ha
 	if req.Number != nil {
 		hall.Number = *req.Number
 	}
+	if req.Name != nil {
+		hall.Name = *req.Name
+	}
+	if req.Format != nil {
+		hall.Format = *req.Format
+	}
+	if req.SoundSystem != nil {
+		hall.SoundSystem = *req.SoundSystem
+	}
+	if req.Status != nil {
+		hall.Status = *req.Status
+	}
 
 	if err := s.hallRepo.Update(id, hall); err != nil {
 		return nil, err
@@ -54,7 +66,17 @@ func (s *hallService) UpdateHall(id uint, req dto.UpdateHallRequest) (*models.Ha
 
 func (s *hallService) CreateHall(req dto.CreateHallRequest) (*models.Hall, error) {
 	hall := models.Hall{
-		Number: req.Number,
+		Number:      req.Number,
+		Name:        req.Name,
+		Format:      req.Format,
+		SoundSystem: req.SoundSystem,
+		Status:      req.Status,
+	}
+	if hall.Format == "" {
+		hall.Format = models.HallFormat2D
+	}
+	if hall.Status == "" {
+		hall.Status = models.HallStatusActive
 	}
 	if err := s.hallRepo.Create(&hall); err != nil {
 		s.logger.Error("service: failed to create hall", "err", err)
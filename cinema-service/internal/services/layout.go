@@ -0,0 +1,134 @@
+package services
+
+import (
+	"cinema-service/internal/dto"
+	"cinema-service/internal/models"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var ErrInvalidLayout = errors.New("invalid hall layout")
+
+// buildSeats expands a layout into the seats of a hall.
+func buildSeats(hallID uint, layout dto.HallLayout) ([]models.Seat, error) {
+	var seats []models.Seat
+	rows := make(map[int]bool, len(layout.Rows))
+
+	for _, row := range layout.Rows {
+		if rows[row.Row] {
+			return nil, fmt.Errorf("%w: row %d defined twice", ErrInvalidLayout, row.Row)
+		}
+		rows[row.Row] = true
+
+		inRow := func(n int) bool { return n >= 1 && n <= row.Seats }
+
+		types := make(map[int]models.SeatType, row.Seats)
+		for _, r := range row.Types {
+			if !inRow(r.From) || !inRow(r.To) {
+				return nil, fmt.Errorf("%w: row %d type range %d-%d is outside the row", ErrInvalidLayout, row.Row, r.From, r.To)
+			}
+			for n := r.From; n <= r.To; n++ {
+				types[n] = r.Type
+			}
+		}
+		for _, n := range row.Wheelchair {
+			if !inRow(n) {
+				return nil, fmt.Errorf("%w: row %d wheelchair space %d is outside the row", ErrInvalidLayout, row.Row, n)
+			}
+			types[n] = models.SeatTypeWheelchair
+		}
+
+		gaps := make(map[int]bool, len(row.Gaps))
+		for _, n := range row.Gaps {
+			if !inRow(n) {
+				return nil, fmt.Errorf("%w: row %d gap %d is outside the row", ErrInvalidLayout, row.Row, n)
+			}
+			if types[n] == models.SeatTypeWheelchair {
+				return nil, fmt.Errorf("%w: row %d position %d is both a gap and a wheelchair space", ErrInvalidLayout, row.Row, n)
+			}
+			gaps[n] = true
+		}
+
+		for n := 1; n <= row.Seats; n++ {
+			if gaps[n] {
+				continue
+			}
+
+			seatType, ok := types[n]
+			if !ok {
+				seatType = models.SeatTypeStandard
+			}
+
+			seats = append(seats, models.Seat{
+				HallID: hallID,
+				Row:    row.Row,
+				Number: n,
+				Type:   seatType,
+			})
+		}
+	}
+
+	if len(seats) == 0 {
+		return nil, fmt.Errorf("%w: layout has no seats", ErrInvalidLayout)
+	}
+
+	return seats, nil
+}
+
+// exportLayout is the inverse of buildSeats; applying its result to an empty
+// hall recreates the same seats.
+func exportLayout(seats []models.Seat) dto.HallLayout {
+	byRow := make(map[int][]models.Seat)
+	for _, seat := range seats {
+		byRow[seat.Row] = append(byRow[seat.Row], seat)
+	}
+
+	rowNumbers := make([]int, 0, len(byRow))
+	for row := range byRow {
+		rowNumbers = append(rowNumbers, row)
+	}
+	sort.Ints(rowNumbers)
+
+	layout := dto.HallLayout{Rows: make([]dto.LayoutRow, 0, len(rowNumbers))}
+	for _, rowNumber := range rowNumbers {
+		rowSeats := byRow[rowNumber]
+		sort.Slice(rowSeats, func(i, j int) bool { return rowSeats[i].Number < rowSeats[j].Number })
+
+		row := dto.LayoutRow{
+			Row:   rowNumber,
+			Seats: rowSeats[len(rowSeats)-1].Number,
+		}
+
+		present := make(map[int]bool, len(rowSeats))
+		var current *dto.SeatRange
+		for _, seat := range rowSeats {
+			present[seat.Number] = true
+
+			if seat.Type == models.SeatTypeWheelchair {
+				row.Wheelchair = append(row.Wheelchair, seat.Number)
+			}
+
+			if seat.Type != models.SeatTypeVip {
+				current = nil
+				continue
+			}
+			if current != nil && current.To == seat.Number-1 {
+				current.To = seat.Number
+				continue
+			}
+			row.Types = append(row.Types, dto.SeatRange{From: seat.Number, To: seat.Number, Type: seat.Type})
+			current = &row.Types[len(row.Types)-1]
+		}
+
+		for n := 1; n <= row.Seats; n++ {
+			if !present[n] {
+				row.Gaps = append(row.Gaps, n)
+			}
+		}
+
+		layout.Rows = append(layout.Rows, row)
+	}
+
+	return layout
+}
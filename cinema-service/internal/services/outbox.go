@@ -0,0 +1,59 @@
+package services
+
+import (
+	"cinema-service/internal/kafka"
+	"cinema-service/internal/models"
+	"encoding/json"
+	"time"
+)
+
+// sessionCancelledEvent tells booking-service to cancel and refund the
+// session's bookings.
+func sessionCancelledEvent(session *models.Session) (models.OutboxEvent, error) {
+	return newOutboxEvent(kafka.TopicSessionCancelled, kafka.SessionKey(session.ID), kafka.SessionCancelledEvent{
+		SessionID:   session.ID,
+		MovieID:     session.MovieID,
+		HallID:      session.HallID,
+		StartTime:   session.StartTime,
+		EndTime:     session.EndTime,
+		CancelledAt: time.Now(),
+	})
+}
+
+func sessionCancelledEvents(sessions []models.Session) ([]models.OutboxEvent, error) {
+	events := make([]models.OutboxEvent, 0, len(sessions))
+	for i := range sessions {
+		event, err := sessionCancelledEvent(&sessions[i])
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// sessionRescheduledEvent lets booking-service refresh the session times it
+// keeps on bookings.
+func sessionRescheduledEvent(session *models.Session, oldStart, oldEnd time.Time) (models.OutboxEvent, error) {
+	return newOutboxEvent(kafka.TopicSessionRescheduled, kafka.SessionKey(session.ID), kafka.SessionRescheduledEvent{
+		SessionID:     session.ID,
+		OldStartTime:  oldStart,
+		OldEndTime:    oldEnd,
+		StartTime:     session.StartTime,
+		EndTime:       session.EndTime,
+		RescheduledAt: time.Now(),
+	})
+}
+
+func newOutboxEvent(topic, key string, event any) (models.OutboxEvent, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return models.OutboxEvent{}, err
+	}
+
+	return models.OutboxEvent{
+		Topic:   topic,
+		Key:     key,
+		Payload: payload,
+	}, nil
+}
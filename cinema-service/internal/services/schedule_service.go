@@ -0,0 +1,412 @@
+package services
+
+import (
+	"cinema-service/internal/clients"
+	"cinema-service/internal/dto"
+	"cinema-service/internal/models"
+	"cinema-service/internal/repository"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+const (
+	dateLayout      = "2006-01-02"
+	startTimeLayout = "15:04"
+	maxScheduleDays = 366
+)
+
+type ScheduleService interface {
+	Create(req dto.CreateScheduleRequest, dryRun bool) (*dto.ScheduleResult, error)
+	Update(id uint, req dto.UpdateScheduleRequest, dryRun bool) (*dto.ScheduleResult, error)
+	Cancel(id uint, dryRun bool) (*dto.ScheduleResult, error)
+	List() ([]models.ScheduleTemplate, error)
+	GetById(id uint) (*models.ScheduleTemplate, error)
+}
+
+type scheduleService struct {
+	scheduleRepo   repository.ScheduleRepository
+	sessionRepo    repository.SessionRepository
+	hallRepo       repository.HallRepository
+	cleaningBuffer time.Duration
+	adPadding      time.Duration
+	location       *time.Location
+	logger         *slog.Logger
+}
+
+func NewScheduleService(
+	scheduleRepo repository.ScheduleRepository,
+	sessionRepo repository.SessionRepository,
+	hallRepo repository.HallRepository,
+	cleaningBuffer time.Duration,
+	adPadding time.Duration,
+	location *time.Location,
+	logger *slog.Logger,
+) ScheduleService {
+	return &scheduleService{
+		scheduleRepo:   scheduleRepo,
+		sessionRepo:    sessionRepo,
+		hallRepo:       hallRepo,
+		cleaningBuffer: cleaningBuffer,
+		adPadding:      adPadding,
+		location:       location,
+		logger:         logger,
+	}
+}
+
+func (s *scheduleService) Create(req dto.CreateScheduleRequest, dryRun bool) (*dto.ScheduleResult, error) {
+	template := &models.ScheduleTemplate{
+		MovieID:    req.MovieID,
+		HallID:     req.HallID,
+		Weekdays:   req.Weekdays,
+		StartTimes: req.StartTimes,
+		Status:     models.ScheduleStatusActive,
+	}
+
+	var err error
+	if template.StartDate, err = time.Parse(dateLayout, req.StartDate); err != nil {
+		return nil, err
+	}
+	if template.EndDate, err = time.Parse(dateLayout, req.EndDate); err != nil {
+		return nil, err
+	}
+
+	return s.apply(template, nil, dryRun)
+}
+
+func (s *scheduleService) Update(id uint, req dto.UpdateScheduleRequest, dryRun bool) (*dto.ScheduleResult, error) {
+	template, err := s.scheduleRepo.GetById(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if template.Status == models.ScheduleStatusCancelled {
+		return nil, ErrScheduleCancelled
+	}
+
+	if req.HallID != nil {
+		template.HallID = *req.HallID
+	}
+	if req.Weekdays != nil {
+		template.Weekdays = req.Weekdays
+	}
+	if req.StartTimes != nil {
+		template.StartTimes = req.StartTimes
+	}
+	if req.StartDate != nil {
+		if template.StartDate, err = time.Parse(dateLayout, *req.StartDate); err != nil {
+			return nil, err
+		}
+	}
+	if req.EndDate != nil {
+		if template.EndDate, err = time.Parse(dateLayout, *req.EndDate); err != nil {
+			return nil, err
+		}
+	}
+
+	existing, err := s.scheduleRepo.ListUpcomingSessions(template.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.apply(template, existing, dryRun)
+}
+
+// Cancel stops the series and cancels all of its upcoming sessions.
+func (s *scheduleService) Cancel(id uint, dryRun bool) (*dto.ScheduleResult, error) {
+	template, err := s.scheduleRepo.GetById(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if template.Status == models.ScheduleStatusCancelled {
+		return nil, ErrScheduleCancelled
+	}
+
+	existing, err := s.scheduleRepo.ListUpcomingSessions(template.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	template.Status = models.ScheduleStatusCancelled
+	result := &dto.ScheduleResult{
+		Template:  *template,
+		DryRun:    dryRun,
+		Created:   []models.Session{},
+		Kept:      []models.Session{},
+		Cancelled: existing,
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	events, err := sessionCancelledEvents(existing)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.scheduleRepo.Apply(template, sessionIDs(existing), nil, events); err != nil {
+		return nil, err
+	}
+
+	markCancelled(result.Cancelled)
+	s.logger.Info(
+		"schedule cancelled",
+		"template_id", template.ID,
+		"cancelled_sessions", len(existing),
+	)
+
+	return result, nil
+}
+
+func (s *scheduleService) List() ([]models.ScheduleTemplate, error) {
+	return s.scheduleRepo.List()
+}
+
+func (s *scheduleService) GetById(id uint) (*models.ScheduleTemplate, error) {
+	return s.scheduleRepo.GetById(id)
+}
+
+// apply plans the template's upcoming sessions and reconciles them with the
+// existing ones: matching slots are kept, the rest are cancelled and missing
+// slots are created. Nothing is written on dry runs or when any new slot
+// conflicts with another session.
+func (s *scheduleService) apply(template *models.ScheduleTemplate, existing []models.Session, dryRun bool) (*dto.ScheduleResult, error) {
+	if template.EndDate.Before(template.StartDate) {
+		return nil, ErrInvalidDateRange
+	}
+	if template.EndDate.Sub(template.StartDate) > maxScheduleDays*24*time.Hour {
+		return nil, ErrScheduleTooLong
+	}
+
+	hall, err := s.hallRepo.GetById(template.HallID)
+	if err != nil {
+		s.logger.Warn(
+			"hall not found while planning schedule",
+			"hall_id", template.HallID,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	if hall.Status != models.HallStatusActive {
+		return nil, ErrHallUnavailable
+	}
+
+	movie, err := clients.GetMovie(template.MovieID)
+	if err != nil {
+		s.logger.Warn(
+			"movie lookup failed while planning schedule",
+			"movie_id", template.MovieID,
+			"err", err,
+		)
+		return nil, err
+	}
+	if movie.MovieStatus == "ended" {
+		return nil, ErrMovieEnded
+	}
+
+	length := time.Duration(movie.Duration)*time.Minute + s.adPadding
+	planned, err := s.plan(template, length)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.ScheduleResult{
+		Template:  *template,
+		DryRun:    dryRun,
+		Created:   []models.Session{},
+		Kept:      []models.Session{},
+		Cancelled: []models.Session{},
+	}
+
+	slots := make(map[int64]models.Session, len(existing))
+	for _, session := range existing {
+		slots[session.StartTime.Unix()] = session
+	}
+
+	for _, session := range planned {
+		current, ok := slots[session.StartTime.Unix()]
+		if ok && current.HallID == session.HallID && current.EndTime.Equal(session.EndTime) {
+			result.Kept = append(result.Kept, current)
+			delete(slots, session.StartTime.Unix())
+			continue
+		}
+		result.Created = append(result.Created, session)
+	}
+
+	for _, session := range existing {
+		if _, ok := slots[session.StartTime.Unix()]; ok {
+			result.Cancelled = append(result.Cancelled, session)
+		}
+	}
+
+	conflicts, err := s.findConflicts(result)
+	if err != nil {
+		return nil, err
+	}
+	result.Conflicts = conflicts
+
+	if dryRun {
+		return result, nil
+	}
+	if len(conflicts) > 0 {
+		s.logger.Warn(
+			"schedule rejected due to conflicts",
+			"template_id", template.ID,
+			"hall_id", template.HallID,
+			"conflicts", len(conflicts),
+		)
+		return result, ErrScheduleConflict
+	}
+
+	events, err := sessionCancelledEvents(result.Cancelled)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.scheduleRepo.Apply(template, sessionIDs(result.Cancelled), result.Created, events); err != nil {
+		return nil, err
+	}
+
+	result.Template = *template
+	markCancelled(result.Cancelled)
+	s.logger.Info(
+		"schedule applied",
+		"template_id", template.ID,
+		"created", len(result.Created),
+		"kept", len(result.Kept),
+		"cancelled", len(result.Cancelled),
+	)
+
+	return result, nil
+}
+
+// plan expands the template into sessions starting after now, ordered by
+// start time.
+func (s *scheduleService) plan(template *models.ScheduleTemplate, length time.Duration) ([]models.Session, error) {
+	weekdays := make(map[time.Weekday]bool, len(template.Weekdays))
+	for _, day := range template.Weekdays {
+		weekdays[time.Weekday(day)] = true
+	}
+
+	starts := make([]time.Time, 0, len(template.StartTimes))
+	seen := make(map[string]bool, len(template.StartTimes))
+	for _, value := range template.StartTimes {
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+
+		t, err := time.Parse(startTimeLayout, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start time %q: %w", value, err)
+		}
+		starts = append(starts, t)
+	}
+
+	now := time.Now()
+	var sessions []models.Session
+	for day := template.StartDate; !day.After(template.EndDate); day = day.AddDate(0, 0, 1) {
+		if !weekdays[day.Weekday()] {
+			continue
+		}
+
+		for _, t := range starts {
+			start := time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, s.location)
+			if !start.After(now) {
+				continue
+			}
+
+			sessions = append(sessions, models.Session{
+				MovieID:   template.MovieID,
+				HallID:    template.HallID,
+				StartTime: start,
+				EndTime:   start.Add(length),
+				Status:    models.SessionStatusScheduled,
+			})
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartTime.Before(sessions[j].StartTime)
+	})
+
+	return sessions, nil
+}
+
+// findConflicts checks every session to be created against other sessions in
+// the hall, ignoring the ones this change cancels, and against the rest of
+// the series.
+func (s *scheduleService) findConflicts(result *dto.ScheduleResult) ([]dto.ScheduleConflict, error) {
+	cancelled := make(map[uint]bool, len(result.Cancelled))
+	for _, session := range result.Cancelled {
+		cancelled[session.ID] = true
+	}
+
+	series := append(append([]models.Session{}, result.Kept...), result.Created...)
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].StartTime.Before(series[j].StartTime)
+	})
+
+	var conflicts []dto.ScheduleConflict
+	for _, session := range result.Created {
+		overlapping, err := s.sessionRepo.FindOverlapping(
+			session.HallID,
+			session.StartTime.Add(-s.cleaningBuffer),
+			session.EndTime.Add(s.cleaningBuffer),
+			0,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, other := range overlapping {
+			if cancelled[other.ID] {
+				continue
+			}
+			conflicts = append(conflicts, dto.ScheduleConflict{
+				StartTime: session.StartTime,
+				EndTime:   session.EndTime,
+				Reason: fmt.Sprintf("overlaps session %d (%s - %s)",
+					other.ID,
+					other.StartTime.Format(time.RFC3339),
+					other.EndTime.Format(time.RFC3339),
+				),
+			})
+			break
+		}
+	}
+
+	for i := 1; i < len(series); i++ {
+		prev, next := series[i-1], series[i]
+		if next.StartTime.Before(prev.EndTime.Add(s.cleaningBuffer)) {
+			conflicts = append(conflicts, dto.ScheduleConflict{
+				StartTime: next.StartTime,
+				EndTime:   next.EndTime,
+				Reason: fmt.Sprintf("overlaps the series slot at %s",
+					prev.StartTime.Format(time.RFC3339),
+				),
+			})
+		}
+	}
+
+	return conflicts, nil
+}
+
+// markCancelled shows the cancellation Apply made on the returned sessions.
+func markCancelled(sessions []models.Session) {
+	for i := range sessions {
+		sessions[i].Status = models.SessionStatusCancelled
+	}
+}
+
+func sessionIDs(sessions []models.Session) []uint {
+	ids := make([]uint, 0, len(sessions))
+	for _, session := range sessions {
+		ids = append(ids, session.ID)
+	}
+	return ids
+}
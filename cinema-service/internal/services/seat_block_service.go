@@ -0,0 +1,159 @@
+package services
+
+import (
+	"cinema-service/internal/dto"
+	"cinema-service/internal/models"
+	"cinema-service/internal/repository"
+	"errors"
+	"log/slog"
+)
+
+var (
+	ErrSeatAlreadyBlocked = errors.New("seat is already blocked")
+	ErrSeatNotInHall      = errors.New("seat does not belong to the session's hall")
+)
+
+type SeatBlockService interface {
+	Block(seatID uint, req dto.CreateSeatBlockRequest) (*models.SeatBlock, error)
+	Unblock(id uint) error
+	ListBySeat(seatID uint) ([]models.SeatBlock, error)
+	ListForSession(sessionID uint) ([]models.SeatBlock, error)
+	SessionSeats(sessionID uint) ([]dto.SessionSeat, error)
+}
+
+type seatBlockService struct {
+	blockRepo   repository.SeatBlockRepository
+	seatRepo    repository.SeatRepository
+	sessionRepo repository.SessionRepository
+	logger      *slog.Logger
+}
+
+func NewSeatBlockService(
+	blockRepo repository.SeatBlockRepository,
+	seatRepo repository.SeatRepository,
+	sessionRepo repository.SessionRepository,
+	logger *slog.Logger,
+) SeatBlockService {
+	return &seatBlockService{
+		blockRepo:   blockRepo,
+		seatRepo:    seatRepo,
+		sessionRepo: sessionRepo,
+		logger:      logger,
+	}
+}
+
+func (s *seatBlockService) Block(seatID uint, req dto.CreateSeatBlockRequest) (*models.SeatBlock, error) {
+	seat, err := s.seatRepo.GetById(seatID)
+	if err != nil {
+		s.logger.Warn("seat not found while blocking", "seat_id", seatID)
+		return nil, err
+	}
+
+	if req.SessionID != nil {
+		session, err := s.sessionRepo.GetById(*req.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		if session.HallID != seat.HallID {
+			return nil, ErrSeatNotInHall
+		}
+	}
+
+	existing, err := s.blockRepo.ListBySeat(seatID)
+	if err != nil {
+		return nil, err
+	}
+	for _, block := range existing {
+		if block.SessionID == nil || (req.SessionID != nil && *block.SessionID == *req.SessionID) {
+			return nil, ErrSeatAlreadyBlocked
+		}
+	}
+
+	block := &models.SeatBlock{
+		SeatID:    seatID,
+		SessionID: req.SessionID,
+		Reason:    req.Reason,
+	}
+	if err := s.blockRepo.Create(block); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(
+		"seat blocked",
+		"seat_id", seatID,
+		"session_id", req.SessionID,
+		"reason", req.Reason,
+	)
+	return block, nil
+}
+
+func (s *seatBlockService) Unblock(id uint) error {
+	if _, err := s.blockRepo.GetById(id); err != nil {
+		return err
+	}
+
+	if err := s.blockRepo.Delete(id); err != nil {
+		return err
+	}
+
+	s.logger.Info("seat block removed", "id", id)
+	return nil
+}
+
+func (s *seatBlockService) ListBySeat(seatID uint) ([]models.SeatBlock, error) {
+	if _, err := s.seatRepo.GetById(seatID); err != nil {
+		return nil, err
+	}
+	return s.blockRepo.ListBySeat(seatID)
+}
+
+func (s *seatBlockService) ListForSession(sessionID uint) ([]models.SeatBlock, error) {
+	session, err := s.sessionRepo.GetById(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return s.blockRepo.ListForSession(session.ID, session.HallID)
+}
+
+// SessionSeats lists every seat of the session's hall with its sale state
+// as far as cinema-service knows it; bookings are layered on by
+// booking-service.
+func (s *seatBlockService) SessionSeats(sessionID uint) ([]dto.SessionSeat, error) {
+	session, err := s.sessionRepo.GetById(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	seats, err := s.seatRepo.ListByHall(session.HallID)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := s.blockRepo.ListForSession(session.ID, session.HallID)
+	if err != nil {
+		return nil, err
+	}
+
+	reasons := make(map[uint]string, len(blocks))
+	for _, block := range blocks {
+		reasons[block.SeatID] = block.Reason
+	}
+
+	result := make([]dto.SessionSeat, 0, len(seats))
+	for _, seat := range seats {
+		item := dto.SessionSeat{
+			SeatID: seat.ID,
+			Row:    seat.Row,
+			Number: seat.Number,
+			Type:   seat.Type,
+			State:  dto.SeatStateAvailable,
+		}
+		if reason, blocked := reasons[seat.ID]; blocked {
+			item.State = dto.SeatStateBlocked
+			item.BlockReason = reason
+		}
+		result = append(result, item)
+	}
+
+	return result, nil
+}
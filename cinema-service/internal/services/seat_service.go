@@ -4,7 +4,10 @@ import (
 	"cinema-service/internal/dto"
 	"cinema-service/internal/models"
 	"cinema-service/internal/repository"
+	"errors"
 	"log/slog"
+
+	"gorm.io/gorm"
 )
 
 type SeatService interface {
@@ -12,6 +15,8 @@ type SeatService interface {
 	UpdateSeat(id uint, req dto.UpdateSeatRequest) (*models.Seat, error)
 	List() ([]models.Seat, error)
 	Delete(id uint) error
+	ApplyLayout(hallID uint, layout dto.HallLayout) ([]models.Seat, error)
+	ExportLayout(hallID uint) (*dto.HallLayout, error)
 }
 
 type seatService struct {
@@ -111,3 +116,40 @@ func (s seatService) Delete(id uint) error {
 	s.logger.Info("seat deleted successfully", "id", id)
 	return nil
 }
+
+// ApplyLayout creates every seat of an empty hall in one transaction.
+// Existing seats are never replaced since bookings refer to them.
+func (s *seatService) ApplyLayout(hallID uint, layout dto.HallLayout) ([]models.Seat, error) {
+	seats, err := buildSeats(hallID, layout)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.seatRepo.CreateLayout(hallID, seats); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Warn(
+				"hall not found while applying layout",
+				"hall_id", hallID,
+				"error", err,
+			)
+		}
+		return nil, err
+	}
+
+	s.logger.Info("hall layout applied", "hall_id", hallID, "seats", len(seats))
+	return seats, nil
+}
+
+func (s *seatService) ExportLayout(hallID uint) (*dto.HallLayout, error) {
+	if _, err := s.hallRepo.GetById(hallID); err != nil {
+		return nil, err
+	}
+
+	seats, err := s.seatRepo.ListByHall(hallID)
+	if err != nil {
+		return nil, err
+	}
+
+	layout := exportLayout(seats)
+	return &layout, nil
+}
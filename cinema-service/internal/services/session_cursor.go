@@ -0,0 +1,56 @@
+package services
+
+import (
+	"cinema-service/internal/repository"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultSessionPageSize = 50
+
+// Cursors are opaque to clients: base64 of "<direction>:<start unix nanos>:<id>",
+// where the direction is "asc" or "desc".
+func encodeSessionCursor(c repository.SessionCursor, desc bool) string {
+	raw := fmt.Sprintf("%s:%d:%d", sessionSortDirection(desc), c.StartTime.UnixNano(), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeSessionCursor checks the cursor direction against the sort, so a
+// cursor from a listing in the other order is rejected instead of paging
+// from the wrong side.
+func decodeSessionCursor(value string, desc bool) (*repository.SessionCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	if parts[0] != sessionSortDirection(desc) {
+		return nil, errors.New("cursor does not match sort")
+	}
+
+	n, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	i, err := strconv.ParseUint(parts[2], 10, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.SessionCursor{StartTime: time.Unix(0, n), ID: uint(i)}, nil
+}
+
+func sessionSortDirection(desc bool) string {
+	if desc {
+		return "desc"
+	}
+	return "asc"
+}
@@ -1,44 +1,63 @@
 package services
 
 import (
+	"cinema-service/internal/clients"
 	"cinema-service/internal/dto"
 	"cinema-service/internal/models"
 	"cinema-service/internal/repository"
 	"errors"
+	"fmt"
 	"log/slog"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type SessionService interface {
 	Create(req dto.CreateSessionRequest) (*models.Session, error)
 	Update(id uint, req dto.UpdateSessionRequest) (*models.Session, error)
-	List() ([]models.Session, error)
+	List(query dto.SessionListQuery) (*dto.SessionPage, error)
 	GetById(id uint) (*models.Session, error)
 	Delete(id uint) error
 	ListByMovieID(movieID uint) ([]models.Session, error)
+	ScheduleSummary() ([]repository.MovieSchedule, error)
+	AdvanceStatuses() error
 }
 
 type sessionService struct {
-	sessionRepo repository.SessionRepository
-	hallRepo    repository.HallRepository
-	logger      *slog.Logger
+	sessionRepo    repository.SessionRepository
+	hallRepo       repository.HallRepository
+	cleaningBuffer time.Duration
+	adPadding      time.Duration
+	location       *time.Location
+	outboxRepo     repository.OutboxRepository
+	logger         *slog.Logger
 }
 
 func NewSessionService(
 	sessionRepo repository.SessionRepository,
 	hallRepo repository.HallRepository,
+	cleaningBuffer time.Duration,
+	adPadding time.Duration,
+	location *time.Location,
+	outboxRepo repository.OutboxRepository,
 	logger *slog.Logger,
 ) SessionService {
 	return &sessionService{
-		sessionRepo: sessionRepo,
-		hallRepo:    hallRepo,
-		logger:      logger,
+		sessionRepo:    sessionRepo,
+		hallRepo:       hallRepo,
+		cleaningBuffer: cleaningBuffer,
+		adPadding:      adPadding,
+		location:       location,
+		outboxRepo:     outboxRepo,
+		logger:         logger,
 	}
 }
 
 func (s *sessionService) Create(req dto.CreateSessionRequest) (*models.Session, error) {
 
-	if _, err := s.hallRepo.GetById(req.HallID); err != nil {
+	hall, err := s.hallRepo.GetById(req.HallID)
+	if err != nil {
 		s.logger.Warn(
 			"hall not found while creating session",
 			"hall_id", req.HallID,
@@ -47,6 +66,11 @@ func (s *sessionService) Create(req dto.CreateSessionRequest) (*models.Session,
 		return nil, err
 	}
 
+	if hall.Status != models.HallStatusActive {
+		s.logger.Warn("attempt to schedule session in inactive hall", "hall_id", hall.ID, "status", hall.Status)
+		return nil, ErrHallUnavailable
+	}
+
 	if req.StartTime.Before(time.Now()) {
 		s.logger.Warn(
 			"attempt to create session in the past",
@@ -54,14 +78,42 @@ func (s *sessionService) Create(req dto.CreateSessionRequest) (*models.Session,
 			"movie_id", req.MovieID,
 			"start_time", req.StartTime,
 		)
-		return nil, errors.New("start_time must be in the future")
+		return nil, ErrSessionInPast
+	}
+
+	movie, err := clients.GetMovie(req.MovieID)
+	if err != nil {
+		s.logger.Warn(
+			"movie lookup failed while creating session",
+			"movie_id", req.MovieID,
+			"err", err,
+		)
+		return nil, err
+	}
+
+	if movie.MovieStatus == "ended" {
+		s.logger.Warn("attempt to schedule ended movie", "movie_id", req.MovieID)
+		return nil, ErrMovieEnded
+	}
+
+	endTime := req.StartTime.Add(time.Duration(movie.Duration)*time.Minute + s.adPadding)
+	if req.EndTime != nil {
+		endTime = *req.EndTime
+	}
+
+	if !endTime.After(req.StartTime) {
+		return nil, errors.New("end_time must be after start_time")
+	}
+
+	if err := s.checkOverlap(req.HallID, req.StartTime, endTime, 0); err != nil {
+		return nil, err
 	}
 
 	session := &models.Session{
 		MovieID:   req.MovieID,
 		HallID:    req.HallID,
 		StartTime: req.StartTime,
-		EndTime:   req.EndTime,
+		EndTime:   endTime,
 		Status:    models.SessionStatusScheduled,
 	}
 
@@ -71,7 +123,7 @@ func (s *sessionService) Create(req dto.CreateSessionRequest) (*models.Session,
 			"hall_id", req.HallID,
 			"movie_id", req.MovieID,
 			"start_time", req.StartTime,
-			"end_time", req.EndTime,
+			"end_time", endTime,
 			"err", err,
 		)
 		return nil, err
@@ -92,8 +144,22 @@ func (s *sessionService) Update(id uint, req dto.UpdateSessionRequest) (*models.
 		return nil, err
 	}
 
+	oldStart, oldEnd := session.StartTime, session.EndTime
+
+	if req.StartTime != nil && !req.StartTime.Equal(session.StartTime) && req.StartTime.Before(time.Now()) {
+		s.logger.Warn(
+			"attempt to move session into the past",
+			"session_id", id,
+			"start_time", *req.StartTime,
+		)
+		return nil, ErrSessionInPast
+	}
+
 	if req.StartTime != nil {
+		// moving the start keeps the length unless a new end is given
+		length := session.EndTime.Sub(session.StartTime)
 		session.StartTime = *req.StartTime
+		session.EndTime = session.StartTime.Add(length)
 	}
 	if req.EndTime != nil {
 		session.EndTime = *req.EndTime
@@ -109,11 +175,38 @@ func (s *sessionService) Update(id uint, req dto.UpdateSessionRequest) (*models.
 		return nil, errors.New("end_time must be after start_time")
 	}
 
+	wasCancelled := session.Status == models.SessionStatusCancelled
 	if req.Status != nil {
 		session.Status = models.SessionStatus(*req.Status)
 	}
 
-	if err := s.sessionRepo.Update(id, session); err != nil {
+	if session.Status != models.SessionStatusCancelled {
+		if err := s.checkOverlap(session.HallID, session.StartTime, session.EndTime, session.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	var events []models.OutboxEvent
+	if !wasCancelled && session.Status == models.SessionStatusCancelled {
+		event, err := sessionCancelledEvent(session)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	} else if !session.StartTime.Equal(oldStart) || !session.EndTime.Equal(oldEnd) {
+		event, err := sessionRescheduledEvent(session, oldStart, oldEnd)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if err := s.sessionRepo.Transaction(func(tx *gorm.DB) error {
+		if err := s.sessionRepo.WithTx(tx).Update(id, session); err != nil {
+			return err
+		}
+		return s.outboxRepo.Add(tx, events...)
+	}); err != nil {
 		s.logger.Error(
 			"failed to update session",
 			"session_id", id,
@@ -127,9 +220,58 @@ func (s *sessionService) Update(id uint, req dto.UpdateSessionRequest) (*models.
 	return session, nil
 }
 
-func (s *sessionService) List() ([]models.Session, error) {
+func (s *sessionService) List(query dto.SessionListQuery) (*dto.SessionPage, error) {
+	filter := repository.SessionFilter{
+		From:    query.From,
+		To:      query.To,
+		HallID:  query.HallID,
+		MovieID: query.MovieID,
+		Status:  models.SessionStatus(query.Status),
+		Desc:    query.Sort == "-start_time",
+		Limit:   query.Limit,
+	}
+
+	if query.Date != "" {
+		if query.From != nil || query.To != nil {
+			return nil, ErrInvalidSessionQuery
+		}
+		day, err := time.ParseInLocation("2006-01-02", query.Date, s.location)
+		if err != nil {
+			return nil, ErrInvalidSessionQuery
+		}
+		next := day.AddDate(0, 0, 1)
+		filter.From, filter.To = &day, &next
+	}
+
+	if !query.Paged() {
+		sessions, err := s.sessionRepo.List(filter)
+		if err != nil {
+			s.logger.Error(
+				"failed to list sessions",
+				"err", err,
+			)
+			return nil, err
+		}
+		return &dto.SessionPage{Items: sessions}, nil
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultSessionPageSize
+	}
+
+	if query.Cursor != "" {
+		cursor, err := decodeSessionCursor(query.Cursor, filter.Desc)
+		if err != nil {
+			return nil, ErrInvalidSessionQuery
+		}
+		filter.After = cursor
+	}
+
+	// one extra row tells whether another page exists
+	limit := filter.Limit
+	filter.Limit++
 
-	sessions, err := s.sessionRepo.List()
+	sessions, err := s.sessionRepo.List(filter)
 	if err != nil {
 		s.logger.Error(
 			"failed to list sessions",
@@ -138,7 +280,14 @@ func (s *sessionService) List() ([]models.Session, error) {
 		return nil, err
 	}
 
-	return sessions, nil
+	page := &dto.SessionPage{Items: sessions}
+	if len(sessions) > limit {
+		page.Items = sessions[:limit]
+		last := page.Items[limit-1]
+		page.NextCursor = encodeSessionCursor(repository.SessionCursor{StartTime: last.StartTime, ID: last.ID}, filter.Desc)
+	}
+
+	return page, nil
 }
 
 func (s *sessionService) GetById(id uint) (*models.Session, error) {
@@ -158,7 +307,8 @@ func (s *sessionService) GetById(id uint) (*models.Session, error) {
 
 func (s *sessionService) Delete(id uint) error {
 
-	if _, err := s.sessionRepo.GetById(id); err != nil {
+	session, err := s.sessionRepo.GetById(id)
+	if err != nil {
 		s.logger.Warn(
 			"session not found",
 			"session_id", id,
@@ -167,7 +317,22 @@ func (s *sessionService) Delete(id uint) error {
 		return err
 	}
 
-	if err := s.sessionRepo.Delete(id); err != nil {
+	// deleting a session that could still be attended cancels its bookings
+	var events []models.OutboxEvent
+	if session.Status == models.SessionStatusScheduled || session.Status == models.SessionStatusOngoing {
+		event, err := sessionCancelledEvent(session)
+		if err != nil {
+			return err
+		}
+		events = append(events, event)
+	}
+
+	if err := s.sessionRepo.Transaction(func(tx *gorm.DB) error {
+		if err := s.sessionRepo.WithTx(tx).Delete(id); err != nil {
+			return err
+		}
+		return s.outboxRepo.Add(tx, events...)
+	}); err != nil {
 		s.logger.Error(
 			"failed to delete session",
 			"session_id", id,
@@ -176,6 +341,7 @@ func (s *sessionService) Delete(id uint) error {
 		return err
 	}
 	s.logger.Info("session deleted successfully", "id", id)
+
 	return nil
 }
 
@@ -193,3 +359,77 @@ func (s *sessionService) ListByMovieID(movieID uint) ([]models.Session, error) {
 
 	return sessions, nil
 }
+
+func (s *sessionService) ScheduleSummary() ([]repository.MovieSchedule, error) {
+
+	summary, err := s.sessionRepo.ScheduleSummary(time.Now())
+	if err != nil {
+		s.logger.Error(
+			"failed to get schedule summary",
+			"err", err,
+		)
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// checkOverlap rejects a time range that comes closer than the cleaning
+// buffer to another active session in the same hall.
+func (s *sessionService) checkOverlap(hallID uint, start, end time.Time, excludeID uint) error {
+	overlapping, err := s.sessionRepo.FindOverlapping(
+		hallID,
+		start.Add(-s.cleaningBuffer),
+		end.Add(s.cleaningBuffer),
+		excludeID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(overlapping) == 0 {
+		return nil
+	}
+
+	conflict := overlapping[0]
+	s.logger.Warn(
+		"session overlaps existing session",
+		"hall_id", hallID,
+		"start_time", start,
+		"end_time", end,
+		"conflicting_session_id", conflict.ID,
+	)
+	return fmt.Errorf("%w: session %d runs %s - %s (cleaning buffer %s)",
+		repository.ErrSessionOverlap,
+		conflict.ID,
+		conflict.StartTime.Format(time.RFC3339),
+		conflict.EndTime.Format(time.RFC3339),
+		s.cleaningBuffer,
+	)
+}
+
+// AdvanceStatuses moves sessions through scheduled -> ongoing -> finished
+// based on the current time. Cancelled sessions are never touched.
+func (s *sessionService) AdvanceStatuses() error {
+	now := time.Now()
+
+	finished, err := s.sessionRepo.MarkFinished(now)
+	if err != nil {
+		return err
+	}
+
+	started, err := s.sessionRepo.MarkOngoing(now)
+	if err != nil {
+		return err
+	}
+
+	if started > 0 || finished > 0 {
+		s.logger.Info(
+			"session statuses advanced",
+			"ongoing", started,
+			"finished", finished,
+		)
+	}
+
+	return nil
+}
@@ -2,7 +2,9 @@ package transport
 
 import (
 	"cinema-service/internal/dto"
+	"cinema-service/internal/repository"
 	"cinema-service/internal/services"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -42,6 +44,10 @@ func (h *HallHandler) Create(c *gin.Context) {
 	}
 	hall, err := h.hallService.CreateHall(req)
 	if err != nil {
+		if errors.Is(err, repository.ErrHallNumberTaken) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		h.logger.Error("handler: failed to create hall", "err", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -64,6 +70,10 @@ func (h *HallHandler) Patch(c *gin.Context) {
 	}
 	hall, err := h.hallService.UpdateHall(uint(id), req)
 	if err != nil {
+		if errors.Is(err, repository.ErrHallNumberTaken) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		h.logger.Error("failed to fetch halls")
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -13,14 +13,20 @@ func RegisterRoutes(
 	hallService services.HallService,
 	seatService services.SeatService,
 	sessionsService services.SessionService,
+	scheduleService services.ScheduleService,
+	seatBlockService services.SeatBlockService,
 
 ) {
 
 	hallHandler := NewHallHandler(hallService, logger)
 	seatHandler := NewSeatHandler(seatService, logger)
 	sessionHandler := NewSessionHandler(sessionsService, logger)
+	scheduleHandler := NewScheduleHandler(scheduleService, logger)
+	seatBlockHandler := NewSeatBlockHandler(seatBlockService, logger)
 
 	hallHandler.RegisterRoutes(router)
 	seatHandler.RegisterRoutes(router)
 	sessionHandler.RegisterRoutes(router)
+	scheduleHandler.RegisterRoutes(router)
+	seatBlockHandler.RegisterRoutes(router)
 }
@@ -0,0 +1,174 @@
+package transport
+
+import (
+	"cinema-service/internal/clients"
+	"cinema-service/internal/dto"
+	"cinema-service/internal/repository"
+	"cinema-service/internal/services"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ScheduleHandler struct {
+	scheduleService services.ScheduleService
+	logger          *slog.Logger
+}
+
+func NewScheduleHandler(scheduleService services.ScheduleService, logger *slog.Logger) *ScheduleHandler {
+	return &ScheduleHandler{
+		scheduleService: scheduleService,
+		logger:          logger,
+	}
+}
+
+func (h *ScheduleHandler) RegisterRoutes(r *gin.Engine) {
+	schedules := r.Group("/schedules")
+	{
+		schedules.POST("", h.Create)
+		schedules.GET("", h.List)
+		schedules.GET("/:id", h.GetById)
+		schedules.PATCH("/:id", h.Update)
+		schedules.DELETE("/:id", h.Cancel)
+	}
+}
+
+func (h *ScheduleHandler) Create(c *gin.Context) {
+	var req dto.CreateScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("handler: failed to bind JSON", "err", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dryRun, ok := parseDryRun(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.scheduleService.Create(req, dryRun)
+	if err != nil {
+		h.writeError(c, result, err)
+		return
+	}
+
+	status := http.StatusCreated
+	if dryRun {
+		status = http.StatusOK
+	}
+	c.JSON(status, result)
+}
+
+func (h *ScheduleHandler) List(c *gin.Context) {
+	templates, err := h.scheduleService.List()
+	if err != nil {
+		h.logger.Error("failed to list schedules", "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list schedules"})
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+}
+
+func (h *ScheduleHandler) GetById(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	template, err := h.scheduleService.GetById(uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "schedule not found"})
+			return
+		}
+
+		h.logger.Error("failed to fetch schedule", "id", id, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch schedule"})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+func (h *ScheduleHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req dto.UpdateScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("handler: failed to bind JSON", "err", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dryRun, ok := parseDryRun(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.scheduleService.Update(uint(id), req, dryRun)
+	if err != nil {
+		h.writeError(c, result, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *ScheduleHandler) Cancel(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	dryRun, ok := parseDryRun(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.scheduleService.Cancel(uint(id), dryRun)
+	if err != nil {
+		h.writeError(c, result, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *ScheduleHandler) writeError(c *gin.Context, result *dto.ScheduleResult, err error) {
+	switch {
+	case errors.Is(err, services.ErrScheduleConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "result": result})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "schedule or hall not found"})
+	case errors.Is(err, clients.ErrMovieNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "movie not found"})
+	case errors.Is(err, services.ErrMovieEnded),
+		errors.Is(err, services.ErrHallUnavailable),
+		errors.Is(err, services.ErrScheduleCancelled),
+		errors.Is(err, repository.ErrSessionOverlap):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error("failed to apply schedule", "err", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+func parseDryRun(c *gin.Context) (bool, bool) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid dry_run"})
+		return false, false
+	}
+	return dryRun, true
+}
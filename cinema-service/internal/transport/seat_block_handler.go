@@ -0,0 +1,142 @@
+package transport
+
+import (
+	"cinema-service/internal/dto"
+	"cinema-service/internal/services"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type SeatBlockHandler struct {
+	blockService services.SeatBlockService
+	logger       *slog.Logger
+}
+
+func NewSeatBlockHandler(blockService services.SeatBlockService, logger *slog.Logger) *SeatBlockHandler {
+	return &SeatBlockHandler{
+		blockService: blockService,
+		logger:       logger,
+	}
+}
+
+func (h *SeatBlockHandler) RegisterRoutes(r *gin.Engine) {
+	blocks := r.Group("/")
+	{
+		blocks.POST("/seats/:id/blocks", h.Block)
+		blocks.GET("/seats/:id/blocks", h.ListBySeat)
+		blocks.DELETE("/seat-blocks/:id", h.Unblock)
+		blocks.GET("/sessions/:id/seat-blocks", h.ListForSession)
+		blocks.GET("/sessions/:id/seats", h.SessionSeats)
+	}
+}
+
+func (h *SeatBlockHandler) Block(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req dto.CreateSeatBlockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("handler: failed to bind JSON", "err", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	block, err := h.blockService.Block(uint(id), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "seat or session not found"})
+		case errors.Is(err, services.ErrSeatAlreadyBlocked):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrSeatNotInHall):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			h.logger.Error("failed to block seat", "seat_id", id, "err", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to block seat"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, block)
+}
+
+func (h *SeatBlockHandler) ListBySeat(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	blocks, err := h.blockService.ListBySeat(uint(id))
+	if err != nil {
+		h.writeLookupError(c, "seat not found", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, blocks)
+}
+
+func (h *SeatBlockHandler) Unblock(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.blockService.Unblock(uint(id)); err != nil {
+		h.writeLookupError(c, "seat block not found", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "seat block removed"})
+}
+
+func (h *SeatBlockHandler) ListForSession(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	blocks, err := h.blockService.ListForSession(uint(id))
+	if err != nil {
+		h.writeLookupError(c, "session not found", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, blocks)
+}
+
+func (h *SeatBlockHandler) SessionSeats(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	seats, err := h.blockService.SessionSeats(uint(id))
+	if err != nil {
+		h.writeLookupError(c, "session not found", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, seats)
+}
+
+func (h *SeatBlockHandler) writeLookupError(c *gin.Context, notFound string, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": notFound})
+		return
+	}
+
+	h.logger.Error("seat block request failed", "path", c.FullPath(), "err", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+}
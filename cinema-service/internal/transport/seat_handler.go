@@ -2,12 +2,15 @@ package transport
 
 import (
 	"cinema-service/internal/dto"
+	"cinema-service/internal/repository"
 	"cinema-service/internal/services"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type SeatHandler struct {
@@ -26,6 +29,8 @@ func (h *SeatHandler) RegisterRoutes(r *gin.Engine) {
 	seats := r.Group("/")
 	{
 		seats.POST("/halls/:id/seats", h.Create)
+		seats.PUT("/halls/:id/layout", h.ApplyLayout)
+		seats.GET("/halls/:id/layout", h.ExportLayout)
 		seats.GET("/seats", h.GetAllSeats)
 		seats.PATCH("/seats/:id", h.Patch)
 		seats.DELETE("/seats/:id", h.RemoveSeat)
@@ -104,3 +109,58 @@ func (h *SeatHandler) RemoveSeat(c *gin.Context) {
 	h.logger.Info("handler: seat deleted successfully", "id", id)
 	c.JSON(http.StatusOK, gin.H{"message": "seat deleted successfully"})
 }
+
+func (h *SeatHandler) ApplyLayout(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req dto.HallLayout
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("handler: failed to bind JSON", "err", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	seats, err := h.seatService.ApplyLayout(uint(id), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "hall not found"})
+		case errors.Is(err, repository.ErrHallHasSeats):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrInvalidLayout):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			h.logger.Error("failed to apply hall layout", "hall_id", id, "err", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to apply hall layout"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, seats)
+}
+
+func (h *SeatHandler) ExportLayout(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	layout, err := h.seatService.ExportLayout(uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "hall not found"})
+			return
+		}
+
+		h.logger.Error("failed to export hall layout", "hall_id", id, "err", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to export hall layout"})
+		return
+	}
+
+	c.JSON(http.StatusOK, layout)
+}
@@ -1,7 +1,9 @@
 package transport
 
 import (
+	"cinema-service/internal/clients"
 	"cinema-service/internal/dto"
+	"cinema-service/internal/repository"
 	"cinema-service/internal/services"
 	"errors"
 	"log/slog"
@@ -28,6 +30,7 @@ func (h *SessionHandler) RegisterRoutes(r *gin.Engine) {
 	sessions := r.Group("/")
 	{
 		sessions.GET("/sessions", h.List)
+		sessions.GET("/sessions/summary", h.ScheduleSummary)
 		sessions.GET("/sessions/:id", h.GetById)
 		sessions.POST("/sessions", h.Create)
 		sessions.PATCH("/sessions/:id", h.Update)
@@ -51,6 +54,18 @@ func (h *SessionHandler) Create(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "hall not found"})
 			return
 		}
+		if errors.Is(err, repository.ErrSessionOverlap) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, clients.ErrMovieNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "movie not found"})
+			return
+		}
+		if errors.Is(err, services.ErrMovieEnded) || errors.Is(err, services.ErrHallUnavailable) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 
 		h.logger.Error("failed to create session", "err", err)
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -61,14 +76,29 @@ func (h *SessionHandler) Create(c *gin.Context) {
 }
 
 func (h *SessionHandler) List(c *gin.Context) {
-	sessions, err := h.sessionService.List()
+	var query dto.SessionListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.sessionService.List(query)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidSessionQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
 		h.logger.Error("failed to list sessions", "err", err)
 		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to list sessions"})
 		return
 	}
 
-	c.JSON(http.StatusOK, sessions)
+	if !query.Paged() {
+		c.JSON(http.StatusOK, page.Items)
+		return
+	}
+	c.JSON(http.StatusOK, page)
 }
 
 func (h *SessionHandler) GetById(c *gin.Context) {
@@ -115,6 +145,10 @@ func (h *SessionHandler) Update(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
 			return
 		}
+		if errors.Is(err, repository.ErrSessionOverlap) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 
 		h.logger.Error("failed to update session", "id", id, "err", err)
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -167,3 +201,14 @@ func (h *SessionHandler) ListByMovieID(c *gin.Context) {
 
 	c.JSON(http.StatusOK, sessions)
 }
+
+// ScheduleSummary lets movie-service derive movie statuses from the schedule.
+func (h *SessionHandler) ScheduleSummary(c *gin.Context) {
+	summary, err := h.sessionService.ScheduleSummary()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get schedule summary"})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
@@ -0,0 +1,54 @@
+package workers
+
+import (
+	"cinema-service/internal/kafka"
+	"cinema-service/internal/repository"
+	"context"
+	"log/slog"
+	"time"
+)
+
+const outboxBatchSize = 100
+
+// StartOutboxRelay publishes pending outbox events in insertion order. A
+// failed publish stops the batch so events for a session are never
+// reordered.
+func StartOutboxRelay(ctx context.Context, outbox repository.OutboxRepository, producer *kafka.Producer, logger *slog.Logger) {
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	logger.Info("outbox relay started", "interval", "2 seconds")
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("outbox relay stopped")
+			return
+		case <-ticker.C:
+			relayBatch(ctx, outbox, producer, logger)
+		}
+	}
+}
+
+func relayBatch(ctx context.Context, outbox repository.OutboxRepository, producer *kafka.Producer, logger *slog.Logger) {
+	events, err := outbox.ListPending(outboxBatchSize)
+	if err != nil {
+		return
+	}
+
+	for _, e := range events {
+		publishCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		err := producer.Publish(publishCtx, e.Topic, e.Key, e.Payload)
+		cancel()
+
+		if err != nil {
+			logger.Error("failed to publish outbox event", "id", e.ID, "topic", e.Topic, "err", err)
+			_ = outbox.MarkFailed(e.ID, err)
+			return
+		}
+
+		if err := outbox.MarkPublished(e.ID); err != nil {
+			return
+		}
+	}
+}
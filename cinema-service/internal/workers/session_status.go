@@ -0,0 +1,24 @@
+package workers
+
+import (
+	"cinema-service/internal/services"
+	"log/slog"
+	"time"
+)
+
+func StartSessionStatusWorker(sessionService services.SessionService, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	logger.Info("session status worker started", "interval", interval.String())
+
+	if err := sessionService.AdvanceStatuses(); err != nil {
+		logger.Error("failed to advance session statuses on startup", "err", err)
+	}
+
+	for range ticker.C {
+		if err := sessionService.AdvanceStatuses(); err != nil {
+			logger.Error("failed to advance session statuses", "err", err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"shared/authz"
+
+	"github.com/gin-gonic/gin"
+)
+
+// registerBookingRoutes exposes booking-service. Staff with bookings:read or
+// bookings:write reach every booking; customers with bookings:own reach
+// only theirs, which booking-service checks against the forwarded user.
+func registerBookingRoutes(router *gin.Engine, authenticate gin.HandlerFunc, client *http.Client, baseURL string) {
+	const unavailable = "booking service unavailable"
+
+	router.GET("/api/bookings", authenticate, listBookings(client, baseURL))
+	router.POST("/api/bookings", authenticate, requireOwnOr(authz.PermBookingsWrite), createBooking(client, baseURL))
+
+	router.GET("/api/bookings/:id", authenticate, requireOwnOr(authz.PermBookingsRead),
+		proxy(client, baseURL, "/bookings/:id", unavailable, forwardOwner(authz.PermBookingsRead)))
+	router.POST("/api/bookings/:id/confirm", authenticate, requireOwnOr(authz.PermBookingsWrite),
+		proxy(client, baseURL, "/bookings/:id/confirm", unavailable, forwardOwner(authz.PermBookingsWrite)))
+	router.POST("/api/bookings/:id/cancel", authenticate, requireOwnOr(authz.PermBookingsWrite),
+		proxy(client, baseURL, "/bookings/:id/cancel", unavailable, forwardOwner(authz.PermBookingsWrite)))
+
+	// setting any status or removing a booking is left to staff
+	router.PATCH("/api/bookings/:id", authenticate, authz.RequirePermission(authz.PermBookingsWrite),
+		proxy(client, baseURL, "/bookings/:id", unavailable))
+	router.DELETE("/api/bookings/:id", authenticate, authz.RequirePermission(authz.PermBookingsWrite),
+		proxy(client, baseURL, "/bookings/:id", unavailable))
+}
+
+// requireOwnOr allows callers with the staff permission and customers with
+// bookings:own.
+func requireOwnOr(staff string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !authz.HasPermission(c, staff) && !authz.HasPermission(c, authz.PermBookingsOwn) {
+			authz.Forbid(c, authz.PermBookingsOwn)
+			return
+		}
+		c.Next()
+	}
+}
+
+// forwardOwner names the caller to booking-service unless they act as
+// staff, so it serves customers their own bookings only.
+func forwardOwner(staff string) requestOption {
+	return func(c *gin.Context, req *http.Request) {
+		if !authz.HasPermission(c, staff) {
+			forwardUser(c, req)
+		}
+	}
+}
+
+// listBookings gives staff every booking and customers their own.
+func listBookings(client *http.Client, baseURL string) gin.HandlerFunc {
+	all := proxy(client, baseURL, "/bookings", "booking service unavailable")
+	own := proxy(client, baseURL, "/bookings/user/:user_id", "booking service unavailable")
+
+	return func(c *gin.Context) {
+		switch {
+		case authz.HasPermission(c, authz.PermBookingsRead):
+			all(c)
+		case authz.HasPermission(c, authz.PermBookingsOwn):
+			c.Params = append(c.Params, gin.Param{Key: "user_id", Value: strconv.FormatUint(uint64(c.GetUint("user_id")), 10)})
+			own(c)
+		default:
+			authz.Forbid(c, authz.PermBookingsOwn)
+		}
+	}
+}
+
+func createBooking(client *http.Client, baseURL string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// a JSON null decodes without error but leaves no object to fill
+		var payload map[string]json.RawMessage
+		if err := json.NewDecoder(c.Request.Body).Decode(&payload); err != nil || payload == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+
+		// only staff may waive the age check on a booking
+		var override bool
+		if json.Unmarshal(payload["age_override"], &override) == nil && override && !authz.HasPermission(c, authz.PermBookingsWrite) {
+			authz.Forbid(c, authz.PermBookingsWrite)
+			return
+		}
+
+		// bookings are made for the token's user; the age check relies on it
+		payload["user_id"] = json.RawMessage(strconv.FormatUint(uint64(c.GetUint("user_id")), 10))
+		body, err := json.Marshal(payload)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create request"})
+			return
+		}
+
+		req, err := http.NewRequest("POST", strings.TrimRight(baseURL, "/")+"/bookings", bytes.NewReader(body))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create request"})
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		forwardLanguage(c, req)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			c.JSON(http.StatusBadGateway, gin.H{"error": "booking service unavailable"})
+			return
+		}
+		defer resp.Body.Close()
+
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			c.JSON(http.StatusBadGateway, gin.H{"error": "failed to read response"})
+			return
+		}
+		c.Data(resp.StatusCode, "application/json", b)
+	}
+}
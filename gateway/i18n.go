@@ -0,0 +1,53 @@
+package main
+
+import (
+	"net/http"
+
+	"shared/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// messages translates the gateway's own error messages. Errors passed
+// through from the services are already translated by them.
+var messages = i18n.NewCatalogue(map[string]string{
+	"auth.check_failed":            "failed to verify token",
+	"auth.invalid_header":          "invalid authorization header",
+	"auth.invalid_token":           "invalid token",
+	"auth.missing_header":          "missing authorization header",
+	"auth.permission_required":     "permission required",
+	"request.invalid_body":         "invalid request body",
+	"request.read_failed":          "failed to read request body",
+	"upstream.booking_unavailable": "booking service unavailable",
+	"upstream.cinema_unavailable":  "cinema service unavailable",
+	"upstream.invalid_session":     "failed to decode session",
+	"upstream.movie_unavailable":   "movie service unavailable",
+	"upstream.read_failed":         "failed to read response",
+	"upstream.request_failed":      "failed to create request",
+	"upstream.user_unavailable":    "user service unavailable",
+}, map[string]map[string]string{
+	"ru": {
+		"auth.check_failed":            "не удалось проверить токен",
+		"auth.invalid_header":          "некорректный заголовок Authorization",
+		"auth.invalid_token":           "недействительный токен",
+		"auth.missing_header":          "отсутствует заголовок Authorization",
+		"auth.permission_required":     "требуется разрешение",
+		"request.invalid_body":         "некорректное тело запроса",
+		"request.read_failed":          "не удалось прочитать тело запроса",
+		"upstream.booking_unavailable": "сервис бронирования недоступен",
+		"upstream.cinema_unavailable":  "сервис кинотеатра недоступен",
+		"upstream.invalid_session":     "не удалось разобрать сеанс",
+		"upstream.movie_unavailable":   "сервис фильмов недоступен",
+		"upstream.read_failed":         "не удалось прочитать ответ",
+		"upstream.request_failed":      "не удалось создать запрос",
+		"upstream.user_unavailable":    "сервис пользователей недоступен",
+	},
+})
+
+// forwardLanguage passes the client's Accept-Language on so the services
+// answer in the same language.
+func forwardLanguage(c *gin.Context, req *http.Request) {
+	if value := c.GetHeader("Accept-Language"); value != "" {
+		req.Header.Set("Accept-Language", value)
+	}
+}
@@ -3,15 +3,16 @@ package main
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"shared/authz"
+
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 )
 
 func main() {
@@ -25,6 +26,22 @@ func main() {
 	}
 
 	router := gin.Default()
+	// the gateway faces clients directly unless TRUSTED_PROXIES names a load
+	// balancer in front of it
+	var proxies []string
+	if v := os.Getenv("TRUSTED_PROXIES"); v != "" {
+		proxies = strings.Split(v, ",")
+	}
+	if err := router.SetTrustedProxies(proxies); err != nil {
+		panic(err)
+	}
+	router.Use(messages.Middleware())
+
+	authenticate := authz.Authenticate([]byte(os.Getenv("JWT_SECRET")), checkToken(httpClient, userSvc))
+	registerStaffRoutes(router, authenticate, httpClient, map[string]string{
+		"cinema": cinemaSvc,
+		"movie":  movieSvc,
+	})
 
 	router.POST("/api/auth/register", func(c *gin.Context) {
 		body, err := io.ReadAll(c.Request.Body)
@@ -39,6 +56,7 @@ func main() {
 			return
 		}
 		req.Header.Set("Content-Type", "application/json")
+		forwardLanguage(c, req)
 
 		resp, err := httpClient.Do(req)
 		if err != nil {
@@ -68,6 +86,9 @@ func main() {
 			return
 		}
 		req.Header.Set("Content-Type", "application/json")
+		// user-service locks out by client IP, not the gateway's
+		req.Header.Set("X-Forwarded-For", c.ClientIP())
+		forwardLanguage(c, req)
 
 		resp, err := httpClient.Do(req)
 		if err != nil {
@@ -91,6 +112,7 @@ func main() {
 			return
 		}
 		req.URL.RawQuery = c.Request.URL.RawQuery
+		forwardLanguage(c, req)
 
 		resp, err := httpClient.Do(req)
 		if err != nil {
@@ -115,35 +137,7 @@ func main() {
 			return
 		}
 
-		resp, err := httpClient.Do(req)
-		if err != nil {
-			c.JSON(http.StatusBadGateway, gin.H{"error": "movie service unavailable"})
-			return
-		}
-		defer resp.Body.Close()
-
-		b, err := io.ReadAll(resp.Body)
-		if err != nil {
-			c.JSON(http.StatusBadGateway, gin.H{"error": "failed to read response"})
-			return
-		}
-		c.Data(resp.StatusCode, "application/json", b)
-	})
-
-	router.POST("/api/movies", func(c *gin.Context) {
-		body, err := io.ReadAll(c.Request.Body)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
-			return
-		}
-
-		req, err := http.NewRequest("POST", strings.TrimRight(movieSvc, "/")+"/movies", bytes.NewReader(body))
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create request"})
-			return
-		}
-		req.Header.Set("Content-Type", "application/json")
-
+		forwardLanguage(c, req)
 		resp, err := httpClient.Do(req)
 		if err != nil {
 			c.JSON(http.StatusBadGateway, gin.H{"error": "movie service unavailable"})
@@ -166,6 +160,7 @@ func main() {
 			return
 		}
 		req.URL.RawQuery = c.Request.URL.RawQuery
+		forwardLanguage(c, req)
 
 		resp, err := httpClient.Do(req)
 		if err != nil {
@@ -190,6 +185,7 @@ func main() {
 			return
 		}
 
+		forwardLanguage(c, req)
 		resp, err := httpClient.Do(req)
 		if err != nil {
 			c.JSON(http.StatusBadGateway, gin.H{"error": "cinema service unavailable"})
@@ -205,236 +201,18 @@ func main() {
 		c.Data(resp.StatusCode, "application/json", b)
 	})
 
-	router.POST("/api/sessions", func(c *gin.Context) {
-		body, err := io.ReadAll(c.Request.Body)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
-			return
-		}
-
-		req, err := http.NewRequest("POST", strings.TrimRight(cinemaSvc, "/")+"/sessions", bytes.NewReader(body))
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create request"})
-			return
-		}
-		req.Header.Set("Content-Type", "application/json")
-
-		resp, err := httpClient.Do(req)
-		if err != nil {
-			c.JSON(http.StatusBadGateway, gin.H{"error": "cinema service unavailable"})
-			return
-		}
-		defer resp.Body.Close()
-
-		b, err := io.ReadAll(resp.Body)
-		if err != nil {
-			c.JSON(http.StatusBadGateway, gin.H{"error": "failed to read response"})
-			return
-		}
-		c.Data(resp.StatusCode, "application/json", b)
-	})
-
-	router.GET("/api/bookings", func(c *gin.Context) {
-		if !validateJWT(c) {
-			return
-		}
-
-		req, err := http.NewRequest("GET", strings.TrimRight(bookingSvc, "/")+"/bookings", nil)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create request"})
-			return
-		}
-		req.URL.RawQuery = c.Request.URL.RawQuery
-
-		resp, err := httpClient.Do(req)
-		if err != nil {
-			c.JSON(http.StatusBadGateway, gin.H{"error": "booking service unavailable"})
-			return
-		}
-		defer resp.Body.Close()
-
-		b, err := io.ReadAll(resp.Body)
-		if err != nil {
-			c.JSON(http.StatusBadGateway, gin.H{"error": "failed to read response"})
-			return
-		}
-		c.Data(resp.StatusCode, "application/json", b)
-	})
+	registerBookingRoutes(router, authenticate, httpClient, bookingSvc)
 
-	router.GET("/api/bookings/:id", func(c *gin.Context) {
-		if !validateJWT(c) {
-			return
-		}
-		id := c.Param("id")
+	router.GET("/api/movies/:id/reviews", proxy(httpClient, movieSvc, "/movies/:id/reviews", "movie service unavailable"))
+	router.POST("/api/movies/:id/reviews", authenticate, proxy(httpClient, movieSvc, "/movies/:id/reviews", "movie service unavailable", forwardUser))
+	router.PATCH("/api/reviews/:id", authenticate, proxy(httpClient, movieSvc, "/reviews/:id", "movie service unavailable", forwardUser))
+	router.DELETE("/api/reviews/:id", authenticate, proxy(httpClient, movieSvc, "/reviews/:id", "movie service unavailable", forwardUser))
+	router.GET("/api/reviews", authenticate, authz.RequirePermission(authz.PermReviewsModerate),
+		proxy(httpClient, movieSvc, "/reviews/", "movie service unavailable"))
+	router.PATCH("/api/reviews/:id/moderation", authenticate, authz.RequirePermission(authz.PermReviewsModerate),
+		proxy(httpClient, movieSvc, "/reviews/:id/moderation", "movie service unavailable", forwardUser))
 
-		req, err := http.NewRequest("GET", strings.TrimRight(bookingSvc, "/")+"/bookings/"+id, nil)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create request"})
-			return
-		}
-
-		resp, err := httpClient.Do(req)
-		if err != nil {
-			c.JSON(http.StatusBadGateway, gin.H{"error": "booking service unavailable"})
-			return
-		}
-		defer resp.Body.Close()
-
-		b, err := io.ReadAll(resp.Body)
-		if err != nil {
-			c.JSON(http.StatusBadGateway, gin.H{"error": "failed to read response"})
-			return
-		}
-		c.Data(resp.StatusCode, "application/json", b)
-	})
-
-	router.POST("/api/bookings", func(c *gin.Context) {
-		if !validateJWT(c) {
-			return
-		}
-
-		body, err := io.ReadAll(c.Request.Body)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
-			return
-		}
-
-		req, err := http.NewRequest("POST", strings.TrimRight(bookingSvc, "/")+"/bookings", bytes.NewReader(body))
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create request"})
-			return
-		}
-		req.Header.Set("Content-Type", "application/json")
-
-		resp, err := httpClient.Do(req)
-		if err != nil {
-			c.JSON(http.StatusBadGateway, gin.H{"error": "booking service unavailable"})
-			return
-		}
-		defer resp.Body.Close()
-
-		b, err := io.ReadAll(resp.Body)
-		if err != nil {
-			c.JSON(http.StatusBadGateway, gin.H{"error": "failed to read response"})
-			return
-		}
-		c.Data(resp.StatusCode, "application/json", b)
-	})
-
-	router.PATCH("/api/bookings/:id", func(c *gin.Context) {
-		if !validateJWT(c) {
-			return
-		}
-		id := c.Param("id")
-
-		body, err := io.ReadAll(c.Request.Body)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
-			return
-		}
-
-		req, err := http.NewRequest("PATCH", strings.TrimRight(bookingSvc, "/")+"/bookings/"+id, bytes.NewReader(body))
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create request"})
-			return
-		}
-		req.Header.Set("Content-Type", "application/json")
-
-		resp, err := httpClient.Do(req)
-		if err != nil {
-			c.JSON(http.StatusBadGateway, gin.H{"error": "booking service unavailable"})
-			return
-		}
-		defer resp.Body.Close()
-
-		b, err := io.ReadAll(resp.Body)
-		if err != nil {
-			c.JSON(http.StatusBadGateway, gin.H{"error": "failed to read response"})
-			return
-		}
-		c.Data(resp.StatusCode, "application/json", b)
-	})
-
-	router.DELETE("/api/bookings/:id", func(c *gin.Context) {
-		if !validateJWT(c) {
-			return
-		}
-		id := c.Param("id")
-
-		req, err := http.NewRequest("DELETE", strings.TrimRight(bookingSvc, "/")+"/bookings/"+id, nil)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create request"})
-			return
-		}
-
-		resp, err := httpClient.Do(req)
-		if err != nil {
-			c.JSON(http.StatusBadGateway, gin.H{"error": "booking service unavailable"})
-			return
-		}
-		defer resp.Body.Close()
-
-		b, err := io.ReadAll(resp.Body)
-		if err != nil {
-			c.JSON(http.StatusBadGateway, gin.H{"error": "failed to read response"})
-			return
-		}
-		c.Data(resp.StatusCode, "application/json", b)
-	})
-
-	router.POST("/api/bookings/:id/confirm", func(c *gin.Context) {
-		if !validateJWT(c) {
-			return
-		}
-		id := c.Param("id")
-
-		req, err := http.NewRequest("POST", strings.TrimRight(bookingSvc, "/")+"/bookings/"+id+"/confirm", nil)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create request"})
-			return
-		}
-
-		resp, err := httpClient.Do(req)
-		if err != nil {
-			c.JSON(http.StatusBadGateway, gin.H{"error": "booking service unavailable"})
-			return
-		}
-		defer resp.Body.Close()
-
-		b, err := io.ReadAll(resp.Body)
-		if err != nil {
-			c.JSON(http.StatusBadGateway, gin.H{"error": "failed to read response"})
-			return
-		}
-		c.Data(resp.StatusCode, "application/json", b)
-	})
-
-	router.POST("/api/bookings/:id/cancel", func(c *gin.Context) {
-		if !validateJWT(c) {
-			return
-		}
-		id := c.Param("id")
-
-		req, err := http.NewRequest("POST", strings.TrimRight(bookingSvc, "/")+"/bookings/"+id+"/cancel", nil)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create request"})
-			return
-		}
-
-		resp, err := httpClient.Do(req)
-		if err != nil {
-			c.JSON(http.StatusBadGateway, gin.H{"error": "booking service unavailable"})
-			return
-		}
-		defer resp.Body.Close()
-
-		b, err := io.ReadAll(resp.Body)
-		if err != nil {
-			c.JSON(http.StatusBadGateway, gin.H{"error": "failed to read response"})
-			return
-		}
-		c.Data(resp.StatusCode, "application/json", b)
-	})
+	router.GET("/api/me/recommendations", authenticate, proxy(httpClient, movieSvc, "/me/recommendations", "movie service unavailable", forwardUser))
 
 	router.GET("/api/sessions/:id/aggregate", func(c *gin.Context) {
 		id := c.Param("id")
@@ -445,6 +223,7 @@ func main() {
 			return
 		}
 
+		forwardLanguage(c, req)
 		resp, err := httpClient.Do(req)
 		if err != nil {
 			c.JSON(http.StatusBadGateway, gin.H{"error": "cinema service unavailable"})
@@ -473,6 +252,7 @@ func main() {
 		if movieID != "" {
 			req2, err := http.NewRequest("GET", strings.TrimRight(movieSvc, "/")+"/movies/"+movieID, nil)
 			if err == nil {
+				forwardLanguage(c, req2)
 				r2, err := httpClient.Do(req2)
 				if err == nil && r2 != nil {
 					defer r2.Body.Close()
@@ -488,6 +268,7 @@ func main() {
 		if hallID != "" {
 			req3, err := http.NewRequest("GET", strings.TrimRight(cinemaSvc, "/")+"/halls/"+hallID, nil)
 			if err == nil {
+				forwardLanguage(c, req3)
 				r3, err := httpClient.Do(req3)
 				if err == nil && r3 != nil {
 					defer r3.Body.Close()
@@ -516,7 +297,7 @@ func getEnv(key, def string) string {
 func toIDString(v interface{}) string {
 	switch t := v.(type) {
 	case float64:
-		return strings.TrimRight(strings.TrimRight(fmtFloat(t), ".0"), ".")
+		return strconv.FormatUint(uint64(t), 10)
 	case string:
 		return t
 	default:
@@ -524,27 +305,8 @@ func toIDString(v interface{}) string {
 	}
 }
 
-func fmtFloat(f float64) string {
-	return fmt.Sprintf("%.0f", f)
-}
-
-func validateJWT(c *gin.Context) bool {
-	secret := []byte(os.Getenv("JWT_SECRET"))
-	auth := c.GetHeader("Authorization")
-	if auth == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing authorization header"})
-		return false
-	}
-	parts := strings.SplitN(auth, " ", 2)
-	if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid authorization header"})
-		return false
-	}
-	tokenStr := parts[1]
-	token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) { return secret, nil })
-	if err != nil || !token.Valid {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
-		return false
-	}
-	return true
+// forwardUser tells the service which user the token belongs to. Clients
+// cannot set the header themselves: requests to services are built afresh.
+func forwardUser(c *gin.Context, req *http.Request) {
+	req.Header.Set("X-User-ID", strconv.FormatUint(uint64(c.GetUint("user_id")), 10))
 }
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"shared/authz"
+
+	"github.com/gin-gonic/gin"
+)
+
+// staffRoute is a write endpoint of cinema-service or movie-service. Those
+// services do not check tokens, so the permission is enforced here.
+type staffRoute struct {
+	method     string
+	path       string
+	service    string
+	target     string
+	permission string
+}
+
+// staffRoutes lists every catalogue and hall management route the gateway
+// exposes. Targets take the route's path parameters by name.
+var staffRoutes = []staffRoute{
+	{"POST", "/api/halls", "cinema", "/halls", authz.PermHallsWrite},
+	{"PATCH", "/api/halls/:id", "cinema", "/halls/:id", authz.PermHallsWrite},
+	{"DELETE", "/api/halls/:id", "cinema", "/halls/:id", authz.PermHallsWrite},
+	{"POST", "/api/halls/:id/seats", "cinema", "/halls/:id/seats", authz.PermHallsWrite},
+	{"PUT", "/api/halls/:id/layout", "cinema", "/halls/:id/layout", authz.PermHallsWrite},
+	{"PATCH", "/api/seats/:id", "cinema", "/seats/:id", authz.PermHallsWrite},
+	{"DELETE", "/api/seats/:id", "cinema", "/seats/:id", authz.PermHallsWrite},
+	{"POST", "/api/seats/:id/blocks", "cinema", "/seats/:id/blocks", authz.PermHallsWrite},
+	{"DELETE", "/api/seat-blocks/:id", "cinema", "/seat-blocks/:id", authz.PermHallsWrite},
+
+	{"POST", "/api/sessions", "cinema", "/sessions", authz.PermSessionsWrite},
+	{"PATCH", "/api/sessions/:id", "cinema", "/sessions/:id", authz.PermSessionsWrite},
+	{"DELETE", "/api/sessions/:id", "cinema", "/sessions/:id", authz.PermSessionsWrite},
+	{"POST", "/api/schedules", "cinema", "/schedules", authz.PermSessionsWrite},
+	{"PATCH", "/api/schedules/:id", "cinema", "/schedules/:id", authz.PermSessionsWrite},
+	{"DELETE", "/api/schedules/:id", "cinema", "/schedules/:id", authz.PermSessionsWrite},
+
+	{"POST", "/api/movies", "movie", "/movies/", authz.PermMoviesWrite},
+	{"POST", "/api/movies/import", "movie", "/movies/import", authz.PermMoviesWrite},
+	{"PUT", "/api/movies/:id", "movie", "/movies/:id", authz.PermMoviesWrite},
+	{"DELETE", "/api/movies/:id", "movie", "/movies/:id", authz.PermMoviesWrite},
+	{"PUT", "/api/movies/:id/poster", "movie", "/movies/:id/poster", authz.PermMoviesWrite},
+	{"DELETE", "/api/movies/:id/poster", "movie", "/movies/:id/poster", authz.PermMoviesWrite},
+	{"PUT", "/api/movies/:id/backdrop", "movie", "/movies/:id/backdrop", authz.PermMoviesWrite},
+	{"DELETE", "/api/movies/:id/backdrop", "movie", "/movies/:id/backdrop", authz.PermMoviesWrite},
+	{"POST", "/api/movies/:id/credits", "movie", "/movies/:id/credits/", authz.PermMoviesWrite},
+	{"DELETE", "/api/movies/:id/credits/:creditId", "movie", "/movies/:id/credits/:creditId", authz.PermMoviesWrite},
+	{"POST", "/api/genres", "movie", "/genres/", authz.PermMoviesWrite},
+	{"PUT", "/api/genres/:id", "movie", "/genres/:id", authz.PermMoviesWrite},
+	{"DELETE", "/api/genres/:id", "movie", "/genres/:id", authz.PermMoviesWrite},
+	{"POST", "/api/people", "movie", "/people/", authz.PermMoviesWrite},
+	{"PUT", "/api/people/:id", "movie", "/people/:id", authz.PermMoviesWrite},
+	{"DELETE", "/api/people/:id", "movie", "/people/:id", authz.PermMoviesWrite},
+}
+
+func registerStaffRoutes(router *gin.Engine, authenticate gin.HandlerFunc, client *http.Client, services map[string]string) {
+	for _, route := range staffRoutes {
+		router.Handle(route.method, route.path,
+			authenticate,
+			authz.RequirePermission(route.permission),
+			proxy(client, services[route.service], route.target, route.service+" service unavailable"),
+		)
+	}
+}
+
+// requestOption adds to a request the gateway sends on, such as the
+// caller's identity with forwardUser.
+type requestOption func(c *gin.Context, req *http.Request)
+
+// proxy forwards the request with its body and query to target on the
+// service at baseURL. Bodies are streamed, so uploads and imports pass
+// through without being buffered.
+func proxy(client *http.Client, baseURL, target, unavailable string, options ...requestOption) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req, err := http.NewRequest(c.Request.Method, strings.TrimRight(baseURL, "/")+targetPath(c, target), c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create request"})
+			return
+		}
+		req.ContentLength = c.Request.ContentLength
+		req.URL.RawQuery = c.Request.URL.RawQuery
+		if contentType := c.GetHeader("Content-Type"); contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		forwardLanguage(c, req)
+		for _, option := range options {
+			option(c, req)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			c.JSON(http.StatusBadGateway, gin.H{"error": unavailable})
+			return
+		}
+		defer resp.Body.Close()
+
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			c.JSON(http.StatusBadGateway, gin.H{"error": "failed to read response"})
+			return
+		}
+		c.Data(resp.StatusCode, "application/json", b)
+	}
+}
+
+// targetPath fills the ":name" segments of target with the request's path
+// parameters.
+func targetPath(c *gin.Context, target string) string {
+	segments := strings.Split(target, "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segments[i] = url.PathEscape(c.Param(name))
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"shared/authz"
+
+	"github.com/gin-gonic/gin"
+)
+
+// checkToken asks user-service whether a token is still current, so tokens
+// revoked by a password change stop working here as well as there.
+func checkToken(client *http.Client, userSvc string) authz.TokenCheck {
+	return func(c *gin.Context, _ *authz.Claims) error {
+		req, err := http.NewRequest(http.MethodGet, strings.TrimRight(userSvc, "/")+"/auth/check", nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", c.GetHeader("Authorization"))
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+
+		switch resp.StatusCode {
+		case http.StatusNoContent:
+			return nil
+		case http.StatusUnauthorized:
+			return authz.ErrTokenRevoked
+		default:
+			return fmt.Errorf("token check: user service answered %d", resp.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Command import loads a catalogue feed into the movie database:
+//
+//	go run ./cmd/import -file catalogue.csv [-format csv] [-dry-run]
+//
+// The report is printed to stdout; the exit code is 1 when any row failed.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"movie-service/internal/config"
+	"movie-service/internal/models"
+	"movie-service/internal/repository"
+	"movie-service/internal/services"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	file := flag.String("file", "", "path to the catalogue file")
+	format := flag.String("format", "", "json or csv; taken from the file extension when empty")
+	dryRun := flag.Bool("dry-run", false, "validate and report without saving")
+	flag.Parse()
+
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*file)), ".")
+	}
+
+	// stdout is kept for the report
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, nil))
+
+	db, err := config.SetUpDatabaseConnection(logger)
+	if err != nil {
+		logger.Error("failed to set up database", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	if err := db.AutoMigrate(&models.Movie{}, &models.Genre{}); err != nil {
+		logger.Error("failed to migrate database", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		logger.Error("failed to open catalogue", slog.Any("error", err))
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	movieRepo := repository.NewMovieRepository(db, logger)
+	genreRepo := repository.NewGenreRepository(db, logger)
+	importService := services.NewImportService(movieRepo, genreRepo, logger)
+
+	report, err := importService.Import(*format, f, *dryRun)
+	if err != nil {
+		logger.Error("catalogue import failed", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	out := json.NewEncoder(os.Stdout)
+	out.SetIndent("", "  ")
+	if err := out.Encode(report); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -1,12 +1,17 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"movie-service/internal/config"
+	"movie-service/internal/kafka"
+	"movie-service/internal/messages"
 	"movie-service/internal/models"
 	"movie-service/internal/repository"
 	"movie-service/internal/services"
+	"movie-service/internal/storage"
 	"movie-service/internal/transport"
+	"movie-service/internal/workers"
 	"os"
 
 	"github.com/gin-gonic/gin"
@@ -16,6 +21,7 @@ func main() {
 	logger := config.InitLogger()
 
 	r := gin.Default()
+	r.Use(messages.Catalogue.Middleware())
 
 	db, err := config.SetUpDatabaseConnection(logger)
 	if err != nil {
@@ -23,20 +29,92 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := db.AutoMigrate(&models.Movie{}, &models.Genre{}); err != nil {
+	if err := db.AutoMigrate(&models.Movie{}, &models.Genre{}, &models.Person{}, &models.MovieCredit{}, &models.Review{}, &models.Viewing{}, &models.MovieBooking{}); err != nil {
 		logger.Error("failed to migrate database", "error", err)
 		os.Exit(1)
 	}
 
+	if err := config.ApplyMovieSearchIndex(db); err != nil {
+		logger.Error("failed to create movie search index", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	if err := config.NormalizeAgeRatings(db); err != nil {
+		logger.Error("failed to normalize age ratings", slog.Any("error", err))
+		os.Exit(1)
+	}
+
 	logger.Info("migrations completed")
 
+	store, err := config.NewBlobStore(logger)
+	if err != nil {
+		logger.Error("failed to set up media storage", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if _, ok := store.(*storage.LocalStore); ok {
+		r.Static("/media", config.MediaDir())
+	}
+	maxUploadSize := config.MaxUploadSize()
+
 	movieRepo := repository.NewMovieRepository(db, logger)
 	genreRepo := repository.NewGenreRepository(db, logger)
+	personRepo := repository.NewPersonRepository(db, logger)
+	creditRepo := repository.NewCreditRepository(db, logger)
+	reviewRepo := repository.NewReviewRepository(db, logger)
+	viewingRepo := repository.NewViewingRepository(db, logger)
+	bookingRepo := repository.NewBookingRepository(db, logger)
 
 	movieService := services.NewMovieService(movieRepo, genreRepo, logger)
 	genreService := services.NewGenreService(genreRepo, logger)
+	personService := services.NewPersonService(personRepo, creditRepo, logger)
+	creditService := services.NewCreditService(creditRepo, movieRepo, personRepo, logger)
+	mediaService := services.NewMediaService(movieRepo, store, maxUploadSize, logger)
+	if err := genreService.BackfillSlugs(); err != nil {
+		logger.Error("failed to backfill genre slugs", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	importService := services.NewImportService(movieRepo, genreRepo, logger)
+	reviewService := services.NewReviewService(reviewRepo, movieRepo, viewingRepo, logger)
+	recommendationService := services.NewRecommendationService(bookingRepo, movieRepo, config.PopularityWindow(), logger)
+	statusService := services.NewMovieStatusService(movieRepo, config.MovieEndedGrace(), logger)
+
+	go workers.StartMovieStatusWorker(statusService, config.MovieStatusInterval(), logger)
+
+	kafka.StartBookingEventsConsumer(context.Background(), kafka.GetBroker(), kafka.BookingHandlers{
+		Changed: func(event kafka.BookingEvent) error {
+			switch event.BookingStatus {
+			case kafka.BookingConfirmed:
+				return recommendationService.RecordBooking(&models.MovieBooking{
+					BookingID: event.BookingID,
+					UserID:    event.UserID,
+					MovieID:   event.MovieID,
+				})
+			case kafka.BookingCancelled:
+				return recommendationService.ForgetBooking(event.BookingID)
+			}
+			return nil
+		},
+		Finished: func(event kafka.BookingFinishedEvent) error {
+			// also covers bookings confirmed before they were tracked
+			if err := recommendationService.RecordBooking(&models.MovieBooking{
+				BookingID: event.BookingID,
+				UserID:    event.UserID,
+				MovieID:   event.MovieID,
+				BookedAt:  event.FinishedAt,
+			}); err != nil {
+				return err
+			}
+			return reviewService.RecordViewing(&models.Viewing{
+				BookingID: event.BookingID,
+				UserID:    event.UserID,
+				MovieID:   event.MovieID,
+				WatchedAt: event.FinishedAt,
+			})
+		},
+	}, logger)
 
-	transport.RegisterRoutes(r, movieService, genreService, logger)
+	transport.RegisterRoutes(r, movieService, genreService, personService, creditService, mediaService, importService, reviewService, recommendationService, maxUploadSize, config.MaxImportSize(), config.CatalogLanguage(), logger)
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -0,0 +1,48 @@
+package clients
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+var httpClient = &http.Client{
+	Timeout: 10 * time.Second,
+}
+
+// MovieSchedule is cinema-service's summary of a movie's non-cancelled
+// sessions.
+type MovieSchedule struct {
+	MovieID    uint      `json:"movie_id"`
+	FirstStart time.Time `json:"first_start"`
+	LastEnd    time.Time `json:"last_end"`
+	Upcoming   int64     `json:"upcoming"`
+}
+
+func getCinemaServiceURL() string {
+	url := os.Getenv("CINEMA_SERVICE_URL")
+	if url == "" {
+		return "http://localhost:8081"
+	}
+	return url
+}
+
+func GetScheduleSummary() ([]MovieSchedule, error) {
+	resp, err := httpClient.Get(getCinemaServiceURL() + "/sessions/summary")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cinema service returned status %d for schedule summary", resp.StatusCode)
+	}
+
+	var summary []MovieSchedule
+	if err := json.NewDecoder(resp.Body).Decode(&summary); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
@@ -0,0 +1,18 @@
+package config
+
+import "gorm.io/gorm"
+
+// NormalizeAgeRatings rewrites ratings stored before the rating became an
+// enum: MPAA values and bare ages ("16") become the local "16+" form.
+func NormalizeAgeRatings(db *gorm.DB) error {
+	return db.Exec(`
+UPDATE movies SET age_rating = CASE upper(trim(age_rating))
+	WHEN 'G' THEN '0+'
+	WHEN 'PG' THEN '6+'
+	WHEN 'PG-13' THEN '12+'
+	WHEN 'R' THEN '16+'
+	WHEN 'NC-17' THEN '18+'
+	ELSE trim(age_rating) || '+'
+END
+WHERE upper(trim(age_rating)) IN ('G', 'PG', 'PG-13', 'R', 'NC-17', '0', '6', '12', '16', '18')`).Error
+}
@@ -0,0 +1,17 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+const defaultMaxImportSize = 20 << 20
+
+// MaxImportSize caps a catalogue file sent to the import endpoint in bytes
+// (IMPORT_MAX_SIZE).
+func MaxImportSize() int64 {
+	if size, err := strconv.ParseInt(os.Getenv("IMPORT_MAX_SIZE"), 10, 64); err == nil && size > 0 {
+		return size
+	}
+	return defaultMaxImportSize
+}
@@ -0,0 +1,9 @@
+package config
+
+import "strings"
+
+// CatalogLanguage is the language of the main title, description and name
+// fields (CATALOG_LANGUAGE); other languages live in translations.
+func CatalogLanguage() string {
+	return strings.ToLower(getEnv("CATALOG_LANGUAGE", "en"))
+}
@@ -0,0 +1,16 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+// PopularityWindow is how far back bookings count towards a movie's
+// popularity in recommendations (RECOMMENDATION_POPULARITY_WINDOW).
+func PopularityWindow() time.Duration {
+	d, err := time.ParseDuration(os.Getenv("RECOMMENDATION_POPULARITY_WINDOW"))
+	if err != nil || d <= 0 {
+		return 30 * 24 * time.Hour
+	}
+	return d
+}
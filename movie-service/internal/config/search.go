@@ -0,0 +1,19 @@
+package config
+
+import "gorm.io/gorm"
+
+// ApplyMovieSearchIndex adds a generated tsvector over title and description
+// with a GIN index. The 'simple' configuration is used because titles come in
+// several languages.
+func ApplyMovieSearchIndex(db *gorm.DB) error {
+	if err := db.Exec(`
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS search_vector tsvector
+	GENERATED ALWAYS AS (
+		setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+		setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+	) STORED`).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`CREATE INDEX IF NOT EXISTS idx_movies_search_vector ON movies USING GIN (search_vector)`).Error
+}
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+// MovieStatusInterval controls how often movie statuses are recomputed.
+func MovieStatusInterval() time.Duration {
+	d, err := time.ParseDuration(os.Getenv("MOVIE_STATUS_INTERVAL"))
+	if err != nil || d <= 0 {
+		return 5 * time.Minute
+	}
+	return d
+}
+
+// MovieEndedGrace is how long a movie keeps showing after its last session,
+// or after its release when it was never scheduled, before it ends. It covers
+// the gap until the next week's schedule is published.
+func MovieEndedGrace() time.Duration {
+	d, err := time.ParseDuration(os.Getenv("MOVIE_ENDED_GRACE"))
+	if err != nil || d < 0 {
+		return 14 * 24 * time.Hour
+	}
+	return d
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"movie-service/internal/storage"
+	"os"
+	"strconv"
+)
+
+const defaultMaxUploadSize = 10 << 20
+
+// NewBlobStore picks the media store from STORAGE_DRIVER: "local" (default)
+// keeps files under MEDIA_DIR, "s3" uses an S3 compatible bucket such as
+// MinIO.
+func NewBlobStore(logger *slog.Logger) (storage.BlobStore, error) {
+	driver := getEnv("STORAGE_DRIVER", "local")
+
+	switch driver {
+	case "local":
+		dir := MediaDir()
+		logger.Info("using local media storage", "dir", dir)
+		return storage.NewLocalStore(dir, getEnv("MEDIA_BASE_URL", "http://localhost:8083/media"))
+
+	case "s3":
+		bucket := getEnv("S3_BUCKET", "movie-media")
+		endpoint := getEnv("S3_ENDPOINT", "localhost:9000")
+		useSSL, _ := strconv.ParseBool(os.Getenv("S3_USE_SSL"))
+
+		logger.Info("using s3 media storage", "endpoint", endpoint, "bucket", bucket)
+		return storage.NewS3Store(context.Background(), storage.S3Config{
+			Endpoint:  endpoint,
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+			Bucket:    bucket,
+			UseSSL:    useSSL,
+			PublicURL: getEnv("S3_PUBLIC_URL", "http://"+endpoint+"/"+bucket),
+		})
+	}
+
+	return nil, fmt.Errorf("unknown STORAGE_DRIVER %q", driver)
+}
+
+// MediaDir is where the local store keeps files, served under /media.
+func MediaDir() string {
+	return getEnv("MEDIA_DIR", "./media")
+}
+
+// MaxUploadSize caps a single media upload in bytes (MEDIA_MAX_UPLOAD_SIZE).
+func MaxUploadSize() int64 {
+	if size, err := strconv.ParseInt(os.Getenv("MEDIA_MAX_UPLOAD_SIZE"), 10, 64); err == nil && size > 0 {
+		return size
+	}
+	return defaultMaxUploadSize
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
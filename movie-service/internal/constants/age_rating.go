@@ -0,0 +1,55 @@
+package constants
+
+import "strings"
+
+// AgeRating is the local audience classification: the minimum age followed
+// by a plus sign.
+type AgeRating string
+
+const (
+	AgeRating0  AgeRating = "0+"
+	AgeRating6  AgeRating = "6+"
+	AgeRating12 AgeRating = "12+"
+	AgeRating16 AgeRating = "16+"
+	AgeRating18 AgeRating = "18+"
+)
+
+var ageRatingMinAge = map[AgeRating]int{
+	AgeRating0:  0,
+	AgeRating6:  6,
+	AgeRating12: 12,
+	AgeRating16: 16,
+	AgeRating18: 18,
+}
+
+// MPAARatings maps the US ratings distributors often supply to the closest
+// local rating.
+var MPAARatings = map[string]AgeRating{
+	"G":     AgeRating0,
+	"PG":    AgeRating6,
+	"PG-13": AgeRating12,
+	"R":     AgeRating16,
+	"NC-17": AgeRating18,
+}
+
+// ParseAgeRating accepts a local rating ("16+", "16") or an MPAA rating
+// ("R") and returns the local rating.
+func ParseAgeRating(value string) (AgeRating, bool) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+
+	if rating, ok := MPAARatings[value]; ok {
+		return rating, true
+	}
+
+	rating := AgeRating(strings.TrimSuffix(value, "+") + "+")
+	if _, ok := ageRatingMinAge[rating]; ok {
+		return rating, true
+	}
+
+	return "", false
+}
+
+// MinAge is the youngest age the rating admits.
+func (r AgeRating) MinAge() int {
+	return ageRatingMinAge[r]
+}
@@ -0,0 +1,19 @@
+package constants
+
+type CreditRole string
+
+const (
+	CreditActor    CreditRole = "actor"
+	CreditDirector CreditRole = "director"
+	CreditWriter   CreditRole = "writer"
+	CreditProducer CreditRole = "producer"
+	CreditComposer CreditRole = "composer"
+)
+
+func (r CreditRole) Valid() bool {
+	switch r {
+	case CreditActor, CreditDirector, CreditWriter, CreditProducer, CreditComposer:
+		return true
+	}
+	return false
+}
@@ -1,9 +1,18 @@
 package dto
 
+// Names maps a language code to the genre's display name in it.
 type GenreCreateRequest struct {
-	Name string `json:"name" binding:"required"`
+	Name     string            `json:"name" binding:"required,max=100"`
+	Slug     string            `json:"slug" binding:"omitempty,max=100"`
+	ParentID *uint             `json:"parent_id"`
+	Names    map[string]string `json:"names" binding:"omitempty,dive,keys,min=2,max=10,endkeys,required,max=100"`
 }
 
+// ParentID 0 moves the genre to the top level; an empty Names object clears
+// the translations.
 type GenreUpdateRequest struct {
-	Name *string `json:"name"`
+	Name     *string           `json:"name" binding:"omitempty,max=100"`
+	Slug     *string           `json:"slug" binding:"omitempty,max=100"`
+	ParentID *uint             `json:"parent_id"`
+	Names    map[string]string `json:"names" binding:"omitempty,dive,keys,min=2,max=10,endkeys,required,max=100"`
 }
@@ -0,0 +1,35 @@
+package dto
+
+// CatalogueRow is one movie of a distributor feed. In CSV files the list
+// columns (genres, languages, subtitles) are separated by "|".
+type CatalogueRow struct {
+	ExternalID  string   `json:"external_id" binding:"required,max=100"`
+	Title       string   `json:"title" binding:"required,max=255"`
+	Description string   `json:"description"`
+	Year        uint     `json:"year" binding:"required"`
+	Duration    uint     `json:"duration" binding:"required"`
+	AgeRating   string   `json:"age_rating" binding:"required"`
+	Genres      []string `json:"genres" binding:"dive,max=100"`
+
+	MovieMetadata
+}
+
+// ImportReport lists the outcome of every row. In a dry run nothing is
+// written and Created/Updated tell what would happen.
+type ImportReport struct {
+	DryRun    bool              `json:"dry_run"`
+	Total     int               `json:"total"`
+	Created   int               `json:"created"`
+	Updated   int               `json:"updated"`
+	Failed    int               `json:"failed"`
+	NewGenres []string          `json:"new_genres,omitempty"`
+	Rows      []ImportRowResult `json:"rows"`
+}
+
+type ImportRowResult struct {
+	Row        int    `json:"row"`
+	ExternalID string `json:"external_id,omitempty"`
+	Action     string `json:"action"`
+	MovieID    uint   `json:"movie_id,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
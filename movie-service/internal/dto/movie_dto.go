@@ -1,23 +1,92 @@
 package dto
 
-import "movie-service/internal/constants"
+import (
+	"movie-service/internal/constants"
+	"movie-service/internal/models"
+)
 
 type MovieCreateRequest struct {
-	Title       string                `json:"title" binding:"required"`
-	Description string                `json:"description" binding:"required"`
-	Year        uint                  `json:"year" binding:"required"`
-	Duration    uint                  `json:"duration" binding:"required"`
-	AgeRating   string                `json:"age_rating" binding:"required"`
-	MovieStatus constants.MovieStatus `json:"movie_status" binding:"required"`
-	GenresID    []uint                `json:"genres_id"`
+	Title        string                `json:"title" binding:"required"`
+	Description  string                `json:"description" binding:"required"`
+	Year         uint                  `json:"year" binding:"required"`
+	Duration     uint                  `json:"duration" binding:"required"`
+	AgeRating    string                `json:"age_rating" binding:"required"` // "0+".."18+" or an MPAA rating
+	MovieStatus  constants.MovieStatus `json:"movie_status" binding:"omitempty,oneof=coming_soon now_showing ended"`
+	GenresID     []uint                `json:"genres_id"`
+	StatusLocked bool                  `json:"status_locked"`
+
+	MovieMetadata
 }
 
+// MovieUpdateRequest.MovieStatus only sticks while StatusLocked is true;
+// unlocked statuses are recomputed by the status worker.
 type MovieUpdateRequest struct {
-	Title       *string                `json:"title"`
-	Description *string                `json:"description"`
-	Year        *uint                  `json:"year"`
-	Duration    *uint                  `json:"duration"`
-	AgeRating   *string                `json:"age_rating"`
-	MovieStatus *constants.MovieStatus `json:"movie_status"`
-	GenresID    *[]uint                `json:"genres_id"`
+	Title        *string                `json:"title"`
+	Description  *string                `json:"description"`
+	Year         *uint                  `json:"year"`
+	Duration     *uint                  `json:"duration"`
+	AgeRating    *string                `json:"age_rating"`
+	MovieStatus  *constants.MovieStatus `json:"movie_status" binding:"omitempty,oneof=coming_soon now_showing ended"`
+	GenresID     *[]uint                `json:"genres_id"`
+	StatusLocked *bool                  `json:"status_locked"`
+
+	OriginalTitle    *string   `json:"original_title" binding:"omitempty,max=255"`
+	Country          *string   `json:"country" binding:"omitempty,max=100"`
+	Languages        *[]string `json:"languages"`
+	Subtitles        *[]string `json:"subtitles"`
+	WorldReleaseDate *string   `json:"world_release_date" binding:"omitempty,datetime=2006-01-02"`
+	LocalReleaseDate *string   `json:"local_release_date" binding:"omitempty,datetime=2006-01-02"`
+	PosterURL        *string   `json:"poster_url" binding:"omitempty,url,max=500"`
+	BackdropURL      *string   `json:"backdrop_url" binding:"omitempty,url,max=500"`
+	TrailerURL       *string   `json:"trailer_url" binding:"omitempty,url,max=500"`
+
+	// Translations replaces all translations; {} removes them.
+	Translations map[string]MovieTextRequest `json:"translations" binding:"omitempty,dive,keys,min=2,max=10,endkeys"`
+}
+
+// MovieMetadata holds the optional descriptive fields of a movie. Dates use
+// the "2006-01-02" layout; Translations are keyed by language code.
+type MovieMetadata struct {
+	OriginalTitle    string   `json:"original_title" binding:"omitempty,max=255"`
+	Country          string   `json:"country" binding:"omitempty,max=100"`
+	Languages        []string `json:"languages"`
+	Subtitles        []string `json:"subtitles"`
+	WorldReleaseDate string   `json:"world_release_date" binding:"omitempty,datetime=2006-01-02"`
+	LocalReleaseDate string   `json:"local_release_date" binding:"omitempty,datetime=2006-01-02"`
+	PosterURL        string   `json:"poster_url" binding:"omitempty,url,max=500"`
+	BackdropURL      string   `json:"backdrop_url" binding:"omitempty,url,max=500"`
+	TrailerURL       string   `json:"trailer_url" binding:"omitempty,url,max=500"`
+
+	Translations map[string]MovieTextRequest `json:"translations" binding:"omitempty,dive,keys,min=2,max=10,endkeys"`
+}
+
+type MovieTextRequest struct {
+	Title       string `json:"title" binding:"required,max=255"`
+	Description string `json:"description"`
+}
+
+// MovieListQuery filters GET /movies. Q is a full-text query in web search
+// syntax; Sort defaults to relevance when Q is set and to title otherwise.
+// Genres are slugs; they add to GenreIDs and both include sub-genres.
+type MovieListQuery struct {
+	Q           string   `form:"q"`
+	GenreIDs    []uint   `form:"genre_id"`
+	Genres      []string `form:"genre"`
+	YearFrom    uint     `form:"year_from"`
+	YearTo      uint     `form:"year_to"`
+	AgeRatings  []string `form:"age_rating"`
+	Status      string   `form:"status" binding:"omitempty,oneof=coming_soon now_showing ended"`
+	DurationMin uint     `form:"duration_min"`
+	DurationMax uint     `form:"duration_max"`
+	PersonID    uint     `form:"person_id"`
+	Role        string   `form:"role" binding:"omitempty,oneof=actor director writer producer composer"`
+	Sort        string   `form:"sort" binding:"omitempty,oneof=relevance title -title year -year duration -duration rating -rating"`
+	Limit       int      `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor      string   `form:"cursor"`
+}
+
+type MoviePage struct {
+	Items      []models.Movie `json:"items"`
+	Total      int64          `json:"total"`
+	NextCursor string         `json:"next_cursor,omitempty"`
 }
@@ -0,0 +1,26 @@
+package dto
+
+import "movie-service/internal/constants"
+
+type PersonCreateRequest struct {
+	Name      string `json:"name" binding:"required,max=255"`
+	BirthDate string `json:"birth_date" binding:"omitempty,datetime=2006-01-02"`
+	Country   string `json:"country" binding:"omitempty,max=100"`
+	Bio       string `json:"bio"`
+	PhotoURL  string `json:"photo_url" binding:"omitempty,url,max=500"`
+}
+
+type PersonUpdateRequest struct {
+	Name      *string `json:"name" binding:"omitempty,max=255"`
+	BirthDate *string `json:"birth_date" binding:"omitempty,datetime=2006-01-02"`
+	Country   *string `json:"country" binding:"omitempty,max=100"`
+	Bio       *string `json:"bio"`
+	PhotoURL  *string `json:"photo_url" binding:"omitempty,url,max=500"`
+}
+
+type CreditCreateRequest struct {
+	PersonID  uint                 `json:"person_id" binding:"required"`
+	Role      constants.CreditRole `json:"role" binding:"required,oneof=actor director writer producer composer"`
+	Character string               `json:"character" binding:"omitempty,max=255"`
+	Position  int                  `json:"position" binding:"omitempty,min=0"`
+}
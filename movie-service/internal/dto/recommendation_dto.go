@@ -0,0 +1,17 @@
+package dto
+
+import "movie-service/internal/models"
+
+type RecommendationQuery struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+// Recommendation is a now-showing movie suggested to a user. Reason is
+// "genres" when it matches the genres the user books and "popular" when it
+// fills in from what others book. Score grows with how many of the user's
+// bookings share the movie's genres.
+type Recommendation struct {
+	Movie  models.Movie `json:"movie"`
+	Reason string       `json:"reason"`
+	Score  float64      `json:"score,omitempty"`
+}
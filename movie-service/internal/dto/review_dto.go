@@ -0,0 +1,45 @@
+package dto
+
+import "movie-service/internal/models"
+
+// A review without text is only a rating; there is nothing to moderate, so
+// it is published at once.
+type ReviewCreateRequest struct {
+	Rating int    `json:"rating" binding:"required,min=1,max=10"`
+	Text   string `json:"text" binding:"omitempty,max=5000"`
+}
+
+// Editing a review sends it back to moderation if it has text.
+type ReviewUpdateRequest struct {
+	Rating *int    `json:"rating" binding:"omitempty,min=1,max=10"`
+	Text   *string `json:"text" binding:"omitempty,max=5000"`
+}
+
+type ReviewModerationRequest struct {
+	Status string `json:"status" binding:"required,oneof=approved rejected"`
+	Note   string `json:"note" binding:"omitempty,max=500"`
+}
+
+// ReviewListQuery pages the approved reviews of a movie; Sort defaults to
+// newest first.
+type ReviewListQuery struct {
+	Sort   string `form:"sort" binding:"omitempty,oneof=created_at -created_at rating -rating"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor string `form:"cursor"`
+}
+
+// ReviewModerationQuery pages the moderation queue, oldest first. Status
+// defaults to pending.
+type ReviewModerationQuery struct {
+	Status  string `form:"status" binding:"omitempty,oneof=pending approved rejected"`
+	MovieID uint   `form:"movie_id"`
+	UserID  uint   `form:"user_id"`
+	Limit   int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor  string `form:"cursor"`
+}
+
+type ReviewPage struct {
+	Items      []models.Review `json:"items"`
+	Total      int64           `json:"total"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
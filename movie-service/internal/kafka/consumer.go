@@ -0,0 +1,126 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"shared/retry"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	TopicBookings        = "bookings"
+	TopicBookingFinished = "booking.finished"
+)
+
+// Statuses carried by events on the bookings topic.
+const (
+	BookingConfirmed = "confirmed"
+	BookingCancelled = "cancelled"
+)
+
+// BookingEvent is published by booking-service on the bookings topic when a
+// booking is confirmed or cancelled. Events from before booking_id and
+// movie_id were added carry zeros.
+type BookingEvent struct {
+	BookingID     uint   `json:"booking_id"`
+	SessionID     uint   `json:"session_id"`
+	MovieID       uint   `json:"movie_id"`
+	UserID        uint   `json:"user_id"`
+	BookingStatus string `json:"booking_status"`
+}
+
+// BookingFinishedEvent is published by booking-service once the session of a
+// confirmed booking is over.
+type BookingFinishedEvent struct {
+	BookingID  uint      `json:"booking_id"`
+	UserID     uint      `json:"user_id"`
+	SessionID  uint      `json:"session_id"`
+	MovieID    uint      `json:"movie_id"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+// BookingHandlers receive the booking events movie-service keeps
+// projections of.
+type BookingHandlers struct {
+	Changed  func(event BookingEvent) error
+	Finished func(event BookingFinishedEvent) error
+}
+
+func GetBroker() string {
+	broker := os.Getenv("KAFKA_BROKER")
+	if broker == "" {
+		return "localhost:9092"
+	}
+	return broker
+}
+
+// StartBookingEventsConsumer passes booking events to the handlers,
+// committing an offset only once its handler succeeds. A failing event is
+// retried rather than skipped.
+func StartBookingEventsConsumer(ctx context.Context, broker string, handlers BookingHandlers, logger *slog.Logger) {
+	topics := []string{TopicBookings, TopicBookingFinished}
+
+	r := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     []string{broker},
+		GroupTopics: topics,
+		GroupID:     "movie-service",
+	})
+
+	go func() {
+		defer r.Close()
+
+		logger.Info("kafka consumer started", slog.Any("topics", topics))
+
+		for {
+			msg, err := r.FetchMessage(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					logger.Info("kafka consumer stopped", slog.Any("topics", topics))
+					return
+				}
+				logger.Error("failed to read kafka message", slog.Any("error", err))
+				continue
+			}
+
+			handled := retry.Until(ctx, func() error { return handleBookingEvent(msg, handlers, logger) }, func(err error, attempt int, next time.Duration) {
+				logger.Error("failed to handle booking event", slog.String("topic", msg.Topic), slog.Any("offset", msg.Offset), slog.Int("attempt", attempt), slog.Duration("retry_in", next), slog.Any("error", err))
+			})
+			if !handled {
+				logger.Info("kafka consumer stopped", slog.Any("topics", topics))
+				return
+			}
+
+			if err := r.CommitMessages(ctx, msg); err != nil {
+				logger.Error("failed to commit kafka message", slog.Any("offset", msg.Offset), slog.Any("error", err))
+			}
+		}
+	}()
+}
+
+// handleBookingEvent skips messages that cannot be decoded; retrying them
+// would not help.
+func handleBookingEvent(msg kafka.Message, handlers BookingHandlers, logger *slog.Logger) error {
+	switch msg.Topic {
+	case TopicBookings:
+		var event BookingEvent
+		if err := json.Unmarshal(msg.Value, &event); err != nil {
+			logger.Error("failed to decode booking event", slog.Any("offset", msg.Offset), slog.Any("error", err))
+			return nil
+		}
+		return handlers.Changed(event)
+
+	case TopicBookingFinished:
+		var event BookingFinishedEvent
+		if err := json.Unmarshal(msg.Value, &event); err != nil {
+			logger.Error("failed to decode booking finished event", slog.Any("offset", msg.Offset), slog.Any("error", err))
+			return nil
+		}
+		return handlers.Finished(event)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+// Package messages lists the error messages the service responds with and
+// their translations.
+package messages
+
+import "shared/i18n"
+
+// Catalogue translates the service's error responses.
+var Catalogue = i18n.NewCatalogue(english, map[string]map[string]string{
+	"ru": ru,
+})
+
+// english maps message IDs to the text responses carry. Translations refer
+// to the IDs, so a message can be reworded here alone.
+var english = map[string]string{
+	// handlers
+	"auth.unauthenticated":             "user not authenticated",
+	"credit.create_failed":             "credit create error",
+	"credit.delete_failed":             "failed to remove credit",
+	"credit.list_failed":               "failed to list credits",
+	"credit.movie_or_person_not_found": "movie or person not found",
+	"credit.not_found":                 "credit not found",
+	"genre.create_failed":              "genre create error",
+	"genre.delete_failed":              "failed to delete genre",
+	"genre.get_failed":                 "failed to get genre",
+	"genre.list_failed":                "genre list error",
+	"genre.not_found":                  "genre not found",
+	"import.failed":                    "catalogue import error",
+	"import.file_too_large":            "catalogue file is too large",
+	"media.read_failed":                "failed to read uploaded file",
+	"media.remove_failed":              "media remove error",
+	"media.upload_failed":              "media upload error",
+	"movie.coming_soon_failed":         "failed to get coming soon movies",
+	"movie.create_failed":              "movie create error",
+	"movie.delete_failed":              "failed to delete movie",
+	"movie.get_failed":                 "failed to get movie",
+	"movie.list_failed":                "movie list error",
+	"movie.not_found":                  "movie not found",
+	"movie.now_showing_failed":         "failed to get now showing movies",
+	"person.create_failed":             "person create error",
+	"person.delete_failed":             "failed to delete person",
+	"person.filmography_failed":        "failed to get filmography",
+	"person.get_failed":                "failed to get person",
+	"person.list_failed":               "person list error",
+	"person.not_found":                 "person not found",
+	"recommendation.list_failed":       "failed to get recommendations",
+	"request.file_required":            "multipart field \"file\" is required",
+	"request.invalid_credit_id":        "invalid credit id",
+	"request.invalid_dry_run":          "invalid dry_run",
+	"request.invalid_genre_id":         "invalid genre id",
+	"request.invalid_movie_id":         "invalid movie id",
+	"request.invalid_person_id":        "invalid person id",
+	"request.invalid_review_id":        "invalid review id",
+	"request.invalid_role":             "invalid role",
+	"review.create_failed":             "review create error",
+	"review.delete_failed":             "failed to delete review",
+	"review.list_failed":               "failed to list reviews",
+	"review.moderate_failed":           "review moderation error",
+	"review.not_found":                 "review not found",
+	"review.update_failed":             "review update error",
+
+	// services and repositories
+	"credit.duplicate":         "person already credited in this role",
+	"genre.cycle":              "a genre cannot be moved under itself or its sub-genres",
+	"genre.duplicate":          "genre with this name or slug already exists",
+	"genre.has_children":       "genre has sub-genres: move or delete them first",
+	"genre.invalid_slug":       "invalid slug: use lowercase latin letters, digits and single hyphens",
+	"genre.parent_not_found":   "parent genre not found",
+	"genre.unknown":            "unknown genre",
+	"import.invalid_catalogue": "catalogue could not be parsed",
+	"import.unknown_format":    "unknown catalogue format: use json or csv",
+	"media.invalid_image":      "image could not be decoded",
+	"media.too_large":          "image is too large",
+	"media.unsupported_type":   "unsupported image type: use jpeg, png or webp",
+	"movie.invalid_age_rating": "invalid age rating: use 0+, 6+, 12+, 16+, 18+ or an MPAA rating",
+	"movie.invalid_query":      "invalid movie query: check sort and cursor",
+	"review.duplicate":         "movie already reviewed by this user",
+	"review.invalid_query":     "invalid review query: check sort and cursor",
+	"review.not_eligible":      "only viewers with a finished booking can review this movie",
+	"review.not_owner":         "review belongs to another user",
+}
@@ -0,0 +1,69 @@
+package messages
+
+var ru = map[string]string{
+	// handlers
+	"auth.unauthenticated":             "пользователь не аутентифицирован",
+	"credit.create_failed":             "ошибка добавления участника",
+	"credit.delete_failed":             "не удалось удалить участника",
+	"credit.list_failed":               "не удалось получить список участников",
+	"credit.movie_or_person_not_found": "фильм или персона не найдены",
+	"credit.not_found":                 "участник фильма не найден",
+	"genre.create_failed":              "ошибка создания жанра",
+	"genre.delete_failed":              "не удалось удалить жанр",
+	"genre.get_failed":                 "не удалось получить жанр",
+	"genre.list_failed":                "ошибка получения списка жанров",
+	"genre.not_found":                  "жанр не найден",
+	"import.failed":                    "ошибка импорта каталога",
+	"import.file_too_large":            "файл каталога слишком большой",
+	"media.read_failed":                "не удалось прочитать загруженный файл",
+	"media.remove_failed":              "ошибка удаления изображения",
+	"media.upload_failed":              "ошибка загрузки изображения",
+	"movie.coming_soon_failed":         "не удалось получить список скорых премьер",
+	"movie.create_failed":              "ошибка создания фильма",
+	"movie.delete_failed":              "не удалось удалить фильм",
+	"movie.get_failed":                 "не удалось получить фильм",
+	"movie.list_failed":                "ошибка получения списка фильмов",
+	"movie.not_found":                  "фильм не найден",
+	"movie.now_showing_failed":         "не удалось получить список фильмов в прокате",
+	"person.create_failed":             "ошибка создания персоны",
+	"person.delete_failed":             "не удалось удалить персону",
+	"person.filmography_failed":        "не удалось получить фильмографию",
+	"person.get_failed":                "не удалось получить персону",
+	"person.list_failed":               "ошибка получения списка персон",
+	"person.not_found":                 "персона не найдена",
+	"recommendation.list_failed":       "не удалось получить рекомендации",
+	"request.file_required":            "требуется поле формы \"file\"",
+	"request.invalid_credit_id":        "некорректный id участника",
+	"request.invalid_dry_run":          "некорректное значение dry_run",
+	"request.invalid_genre_id":         "некорректный id жанра",
+	"request.invalid_movie_id":         "некорректный id фильма",
+	"request.invalid_person_id":        "некорректный id персоны",
+	"request.invalid_review_id":        "некорректный id отзыва",
+	"request.invalid_role":             "некорректная роль",
+	"review.create_failed":             "ошибка создания отзыва",
+	"review.delete_failed":             "не удалось удалить отзыв",
+	"review.list_failed":               "не удалось получить список отзывов",
+	"review.moderate_failed":           "ошибка модерации отзыва",
+	"review.not_found":                 "отзыв не найден",
+	"review.update_failed":             "ошибка изменения отзыва",
+
+	// services and repositories
+	"credit.duplicate":         "персона уже указана в этой роли",
+	"genre.cycle":              "жанр нельзя вложить в самого себя или в его поджанры",
+	"genre.duplicate":          "жанр с таким названием или slug уже существует",
+	"genre.has_children":       "у жанра есть поджанры: сначала перенесите или удалите их",
+	"genre.invalid_slug":       "некорректный slug: используйте строчные латинские буквы, цифры и одиночные дефисы",
+	"genre.parent_not_found":   "родительский жанр не найден",
+	"genre.unknown":            "неизвестный жанр",
+	"import.invalid_catalogue": "не удалось разобрать каталог",
+	"import.unknown_format":    "неизвестный формат каталога: используйте json или csv",
+	"media.invalid_image":      "не удалось прочитать изображение",
+	"media.too_large":          "изображение слишком большое",
+	"media.unsupported_type":   "неподдерживаемый тип изображения: используйте jpeg, png или webp",
+	"movie.invalid_age_rating": "некорректный возрастной рейтинг: используйте 0+, 6+, 12+, 16+, 18+ или рейтинг MPAA",
+	"movie.invalid_query":      "некорректный запрос фильмов: проверьте sort и cursor",
+	"review.duplicate":         "пользователь уже оставил отзыв на этот фильм",
+	"review.invalid_query":     "некорректный запрос отзывов: проверьте sort и cursor",
+	"review.not_eligible":      "оставить отзыв могут только зрители, посетившие сеанс этого фильма",
+	"review.not_owner":         "отзыв принадлежит другому пользователю",
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// MovieBooking is a confirmed booking as reported by booking-service, kept
+// so recommendations can be computed without asking it. Cancelled bookings
+// are removed.
+type MovieBooking struct {
+	BookingID uint      `json:"booking_id" gorm:"primaryKey;autoIncrement:false"`
+	UserID    uint      `json:"user_id" gorm:"not null;index"`
+	MovieID   uint      `json:"movie_id" gorm:"not null;index"`
+	BookedAt  time.Time `json:"booked_at" gorm:"not null;index"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "movie-service/internal/constants"
+
+// MovieCredit links a person to a movie in a role. Character is only set for
+// actors; Position orders the credits of one movie.
+type MovieCredit struct {
+	Base
+	MovieID   uint                 `json:"movie_id" gorm:"not null;index;uniqueIndex:idx_credit_movie_person_role,priority:1,where:deleted_at IS NULL"`
+	PersonID  uint                 `json:"person_id" gorm:"not null;index;uniqueIndex:idx_credit_movie_person_role,priority:2"`
+	Person    *Person              `json:"person,omitempty"`
+	Movie     *Movie               `json:"movie,omitempty"`
+	Role      constants.CreditRole `json:"role" gorm:"type:varchar(30);not null;index;uniqueIndex:idx_credit_movie_person_role,priority:3"`
+	Character string               `json:"character,omitempty" gorm:"type:varchar(255);uniqueIndex:idx_credit_movie_person_role,priority:4"`
+	Position  int                  `json:"position" gorm:"not null;default:0"`
+}
@@ -1,6 +1,28 @@
 package models
 
+// Genre is a node of the genre tree. Names holds display names keyed by
+// language ("ru", "en", ...); Name is the canonical one.
 type Genre struct {
 	Base
-	Name string `json:"name" gorm:"type:varchar(100);not null;uniqueIndex"`
+	Name     string            `json:"name" gorm:"type:varchar(100);not null;uniqueIndex"`
+	Slug     string            `json:"slug" gorm:"type:varchar(100);uniqueIndex:idx_genres_slug,where:slug <> '' AND deleted_at IS NULL"`
+	ParentID *uint             `json:"parent_id,omitempty" gorm:"index"`
+	Parent   *Genre            `json:"-"`
+	Children []Genre           `json:"children,omitempty" gorm:"foreignKey:ParentID"`
+	Names    map[string]string `json:"names,omitempty" gorm:"type:jsonb;serializer:json"`
+}
+
+// Localize swaps Name for the first of langs that has a translation, in the
+// genre and its sub-genres.
+func (g *Genre) Localize(langs []string) {
+	for _, lang := range langs {
+		if name, ok := g.Names[lang]; ok && name != "" {
+			g.Name = name
+			break
+		}
+	}
+
+	for i := range g.Children {
+		g.Children[i].Localize(langs)
+	}
 }
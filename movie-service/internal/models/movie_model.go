@@ -1,14 +1,63 @@
 package models
 
-import "movie-service/internal/constants"
+import (
+	"movie-service/internal/constants"
+	"time"
+)
 
+// Movie's MovieStatus is derived from the release date and the schedule
+// unless StatusLocked is set. RatingAverage and RatingCount summarize the
+// approved reviews and are maintained by the review service.
 type Movie struct {
 	Base
-	Title       string                `json:"title" gorm:"type:varchar(255);not null"`
-	Description string                `json:"description" gorm:"type:text;not null"`
-	Year        uint                  `json:"year" gorm:"not null;index"`
-	Duration    uint                  `json:"duration" gorm:"not null"`
-	AgeRating   string                `json:"age_rating" gorm:"type:varchar(50);not null"`
-	MovieStatus constants.MovieStatus `json:"movie_status" gorm:"type:varchar(50);not null"`
-	Genres      []Genre               `json:"genres" gorm:"many2many:movie_genres;"`
+	ExternalID       string                `json:"external_id,omitempty" gorm:"type:varchar(100);uniqueIndex:idx_movies_external_id,where:external_id <> '' AND deleted_at IS NULL"`
+	Title            string                `json:"title" gorm:"type:varchar(255);not null"`
+	OriginalTitle    string                `json:"original_title,omitempty" gorm:"type:varchar(255)"`
+	Description      string                `json:"description" gorm:"type:text;not null"`
+	Year             uint                  `json:"year" gorm:"not null;index"`
+	Duration         uint                  `json:"duration" gorm:"not null"`
+	AgeRating        constants.AgeRating   `json:"age_rating" gorm:"type:varchar(50);not null"`
+	MovieStatus      constants.MovieStatus `json:"movie_status" gorm:"type:varchar(50);not null"`
+	StatusLocked     bool                  `json:"status_locked" gorm:"not null;default:false"`
+	Country          string                `json:"country,omitempty" gorm:"type:varchar(100)"`
+	Languages        []string              `json:"languages" gorm:"type:jsonb;serializer:json"`
+	Subtitles        []string              `json:"subtitles" gorm:"type:jsonb;serializer:json"`
+	WorldReleaseDate *time.Time            `json:"world_release_date,omitempty" gorm:"type:date"`
+	LocalReleaseDate *time.Time            `json:"local_release_date,omitempty" gorm:"type:date;index"`
+	PosterURL        string                `json:"poster_url,omitempty" gorm:"type:varchar(500)"`
+	PosterSizes      map[string]string     `json:"poster_sizes,omitempty" gorm:"type:jsonb;serializer:json"`
+	PosterKey        string                `json:"-" gorm:"type:varchar(255)"`
+	BackdropURL      string                `json:"backdrop_url,omitempty" gorm:"type:varchar(500)"`
+	BackdropSizes    map[string]string     `json:"backdrop_sizes,omitempty" gorm:"type:jsonb;serializer:json"`
+	BackdropKey      string                `json:"-" gorm:"type:varchar(255)"`
+	TrailerURL       string                `json:"trailer_url,omitempty" gorm:"type:varchar(500)"`
+	Translations     map[string]MovieText  `json:"translations,omitempty" gorm:"type:jsonb;serializer:json"`
+	RatingAverage    float64               `json:"rating_average" gorm:"not null;default:0"`
+	RatingCount      uint                  `json:"rating_count" gorm:"not null;default:0"`
+	Genres           []Genre               `json:"genres" gorm:"many2many:movie_genres;"`
+	Credits          []MovieCredit         `json:"credits,omitempty"`
+}
+
+// MovieText is the title and description of a movie in one language.
+type MovieText struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+// Localize swaps the title and description, and the genre names, for the
+// first of langs that has a translation. The main fields are the fallback.
+func (m *Movie) Localize(langs []string) {
+	for _, lang := range langs {
+		if text, ok := m.Translations[lang]; ok && text.Title != "" {
+			m.Title = text.Title
+			if text.Description != "" {
+				m.Description = text.Description
+			}
+			break
+		}
+	}
+
+	for i := range m.Genres {
+		m.Genres[i].Localize(langs)
+	}
 }
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+type Person struct {
+	Base
+	Name      string     `json:"name" gorm:"type:varchar(255);not null;index"`
+	BirthDate *time.Time `json:"birth_date,omitempty" gorm:"type:date"`
+	Country   string     `json:"country,omitempty" gorm:"type:varchar(100)"`
+	Bio       string     `json:"bio,omitempty" gorm:"type:text"`
+	PhotoURL  string     `json:"photo_url,omitempty" gorm:"type:varchar(500)"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"movie-service/internal/constants"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Review is a user's rating of a movie they have seen, with optional text.
+// Only approved reviews are listed publicly and count towards the rating
+// denormalized onto the movie.
+type Review struct {
+	ID             uint                   `json:"id" gorm:"primaryKey"`
+	MovieID        uint                   `json:"movie_id" gorm:"not null;index;uniqueIndex:idx_reviews_movie_user,priority:1,where:deleted_at IS NULL"`
+	UserID         uint                   `json:"user_id" gorm:"not null;index;uniqueIndex:idx_reviews_movie_user,priority:2"`
+	Rating         int                    `json:"rating" gorm:"not null"`
+	Text           string                 `json:"text,omitempty" gorm:"type:text"`
+	Status         constants.ReviewStatus `json:"status" gorm:"type:varchar(20);not null;index"`
+	ModerationNote string                 `json:"moderation_note,omitempty" gorm:"type:varchar(500)"`
+	ModeratedBy    *uint                  `json:"moderated_by,omitempty"`
+	ModeratedAt    *time.Time             `json:"moderated_at,omitempty"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt         `json:"-" gorm:"index"`
+}
+
+// Viewing records that a user attended a session of a movie, as reported by
+// booking-service. Only viewers may review a movie.
+type Viewing struct {
+	BookingID uint      `json:"booking_id" gorm:"primaryKey;autoIncrement:false"`
+	UserID    uint      `json:"user_id" gorm:"not null;index:idx_viewings_user_movie,priority:1"`
+	MovieID   uint      `json:"movie_id" gorm:"not null;index:idx_viewings_user_movie,priority:2"`
+	WatchedAt time.Time `json:"watched_at"`
+}
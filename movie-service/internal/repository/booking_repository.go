@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"log/slog"
+	"movie-service/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BookingRepository interface {
+	Record(booking *models.MovieBooking) error
+
+	Remove(bookingID uint) error
+
+	MovieIDsByUser(userID uint) ([]uint, error)
+
+	Popularity(since time.Time) (map[uint]int64, error)
+}
+
+type gormBookingRepository struct {
+	DB     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewBookingRepository(db *gorm.DB, logger *slog.Logger) BookingRepository {
+	return &gormBookingRepository{
+		DB:     db,
+		logger: logger,
+	}
+}
+
+// Record stores a booking once, so redelivered events keep the first time
+// it was seen.
+func (r *gormBookingRepository) Record(booking *models.MovieBooking) error {
+	if err := r.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(booking).Error; err != nil {
+		r.logger.Error("failed to record booking", slog.Any("booking_id", booking.BookingID), slog.Any("error", err))
+		return err
+	}
+	return nil
+}
+
+func (r *gormBookingRepository) Remove(bookingID uint) error {
+	if err := r.DB.Delete(&models.MovieBooking{}, bookingID).Error; err != nil {
+		r.logger.Error("failed to remove booking", slog.Any("booking_id", bookingID), slog.Any("error", err))
+		return err
+	}
+	return nil
+}
+
+// MovieIDsByUser returns the distinct movies the user has booked.
+func (r *gormBookingRepository) MovieIDsByUser(userID uint) ([]uint, error) {
+
+	var ids []uint
+
+	if err := r.DB.Model(&models.MovieBooking{}).
+		Distinct("movie_id").
+		Where("user_id = ?", userID).
+		Pluck("movie_id", &ids).Error; err != nil {
+		r.logger.Error("failed to list booked movies", slog.Any("user_id", userID), slog.Any("error", err))
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// Popularity counts the bookings of each movie made since the given time.
+func (r *gormBookingRepository) Popularity(since time.Time) (map[uint]int64, error) {
+
+	var rows []struct {
+		MovieID uint
+		Count   int64
+	}
+
+	if err := r.DB.Model(&models.MovieBooking{}).
+		Select("movie_id, COUNT(*) AS count").
+		Where("booked_at >= ?", since).
+		Group("movie_id").
+		Scan(&rows).Error; err != nil {
+		r.logger.Error("failed to count bookings per movie", slog.Any("error", err))
+		return nil, err
+	}
+
+	popularity := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		popularity[row.MovieID] = row.Count
+	}
+
+	return popularity, nil
+}
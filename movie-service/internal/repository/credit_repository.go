@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"errors"
+	"log/slog"
+	"movie-service/internal/constants"
+	"movie-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrDuplicateCredit is returned when the person already holds the same role
+// (and character) in the movie.
+var ErrDuplicateCredit = errors.New("person already credited in this role")
+
+type CreditRepository interface {
+	Create(credit *models.MovieCredit) error
+
+	ListByMovie(movieID uint) ([]models.MovieCredit, error)
+
+	ListByPerson(personID uint, role constants.CreditRole) ([]models.MovieCredit, error)
+
+	Delete(movieID, id uint) error
+}
+
+type gormCreditRepository struct {
+	DB     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewCreditRepository(db *gorm.DB, logger *slog.Logger) CreditRepository {
+	return &gormCreditRepository{
+		DB:     db,
+		logger: logger,
+	}
+}
+
+func (r *gormCreditRepository) Create(credit *models.MovieCredit) error {
+	if err := r.DB.Omit("Person", "Movie").Create(credit).Error; err != nil {
+		if isUniqueViolation(err) {
+			return ErrDuplicateCredit
+		}
+		r.logger.Error("failed to create credit", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
+
+func (r *gormCreditRepository) ListByMovie(movieID uint) ([]models.MovieCredit, error) {
+
+	var credits []models.MovieCredit
+
+	if err := r.DB.
+		Preload("Person").
+		Where("movie_id = ?", movieID).
+		Order("position ASC, id ASC").
+		Find(&credits).Error; err != nil {
+		r.logger.Error("failed to list movie credits", slog.Any("movie_id", movieID), slog.Any("error", err))
+		return nil, err
+	}
+
+	return credits, nil
+}
+
+// ListByPerson returns a person's filmography, newest movies first.
+func (r *gormCreditRepository) ListByPerson(personID uint, role constants.CreditRole) ([]models.MovieCredit, error) {
+
+	var credits []models.MovieCredit
+
+	query := r.DB.
+		Preload("Movie").
+		Joins("JOIN movies ON movies.id = movie_credits.movie_id AND movies.deleted_at IS NULL").
+		Where("movie_credits.person_id = ?", personID)
+	if role != "" {
+		query = query.Where("movie_credits.role = ?", role)
+	}
+
+	if err := query.Order("movies.year DESC, movies.id DESC").Find(&credits).Error; err != nil {
+		r.logger.Error("failed to list person credits", slog.Any("person_id", personID), slog.Any("error", err))
+		return nil, err
+	}
+
+	return credits, nil
+}
+
+func (r *gormCreditRepository) Delete(movieID, id uint) error {
+
+	res := r.DB.Where("movie_id = ?", movieID).Delete(&models.MovieCredit{}, id)
+	if err := res.Error; err != nil {
+		r.logger.Error("failed to delete credit", slog.Any("id", id), slog.Any("error", err))
+		return err
+	}
+
+	if res.RowsAffected == 0 {
+		r.logger.Info("credit not found for delete", slog.Any("id", id))
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -1,12 +1,27 @@
 package repository
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
 	"movie-service/internal/models"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 )
 
+// ErrDuplicateGenre is returned when the name or slug is already taken.
+var ErrDuplicateGenre = errors.New("genre with this name or slug already exists")
+
+// genreSubtree selects the ids of the given genres and all their descendants.
+const genreSubtree = `
+WITH RECURSIVE genre_tree AS (
+	SELECT id FROM genres WHERE id IN ? AND deleted_at IS NULL
+	UNION
+	SELECT g.id FROM genres g JOIN genre_tree t ON g.parent_id = t.id WHERE g.deleted_at IS NULL
+)
+SELECT id FROM genre_tree`
+
 type GenreRepository interface {
 	Create(genre *models.Genre) error
 
@@ -14,6 +29,15 @@ type GenreRepository interface {
 
 	GetByID(id uint) (*models.Genre, error)
 
+	GetByName(name string) (*models.Genre, error)
+
+	GetBySlug(slug string) (*models.Genre, error)
+
+	// SubtreeIDs returns the ids of the genres and all their sub-genres.
+	SubtreeIDs(ids []uint) ([]uint, error)
+
+	ListChildren(id uint) ([]models.Genre, error)
+
 	Update(genre *models.Genre) error
 
 	Delete(id uint) error
@@ -32,8 +56,23 @@ func NewGenreRepository(db *gorm.DB, logger *slog.Logger) GenreRepository {
 
 }
 
+// Create stores the genre. A genre without a slug, because its name has
+// nothing to build one from, gets "genre-<id>" once its id is known.
 func (r *gormGenreRepository) Create(genre *models.Genre) error {
-	if err := r.DB.Create(genre).Error; err != nil {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Parent", "Children").Create(genre).Error; err != nil {
+			return err
+		}
+		if genre.Slug != "" {
+			return nil
+		}
+		genre.Slug = fmt.Sprintf("genre-%d", genre.ID)
+		return tx.Model(genre).Update("slug", genre.Slug).Error
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrDuplicateGenre
+		}
 		r.logger.Error("failed to create genre", slog.Any("error", err))
 		return err
 	}
@@ -65,9 +104,65 @@ func (r *gormGenreRepository) GetByID(id uint) (*models.Genre, error) {
 	return &genre, nil
 }
 
+// GetByName looks a genre up ignoring case.
+func (r *gormGenreRepository) GetByName(name string) (*models.Genre, error) {
+
+	var genre models.Genre
+
+	if err := r.DB.Where("lower(name) = lower(?)", name).First(&genre).Error; err != nil {
+		return nil, err
+	}
+
+	return &genre, nil
+}
+
+func (r *gormGenreRepository) GetBySlug(slug string) (*models.Genre, error) {
+
+	var genre models.Genre
+
+	if err := r.DB.Where("slug = ?", slug).First(&genre).Error; err != nil {
+		r.logger.Info("genre not found by slug", slog.String("slug", slug), slog.Any("error", err))
+		return nil, err
+	}
+
+	return &genre, nil
+}
+
+func (r *gormGenreRepository) SubtreeIDs(ids []uint) ([]uint, error) {
+
+	var subtree []uint
+
+	if err := r.DB.Raw(genreSubtree, ids).Scan(&subtree).Error; err != nil {
+		r.logger.Error("failed to get genre subtree", slog.Any("ids", ids), slog.Any("error", err))
+		return nil, err
+	}
+
+	return subtree, nil
+}
+
+func (r *gormGenreRepository) ListChildren(id uint) ([]models.Genre, error) {
+
+	var children []models.Genre
+
+	if err := r.DB.Where("parent_id = ?", id).Order("id ASC").Find(&children).Error; err != nil {
+		r.logger.Error("failed to list sub-genres", slog.Any("id", id), slog.Any("error", err))
+		return nil, err
+	}
+
+	return children, nil
+}
+
+// Update writes every editable column so a genre can be moved back to the
+// root by clearing ParentID.
 func (r *gormGenreRepository) Update(genre *models.Genre) error {
 
-	if err := r.DB.Model(&models.Genre{}).Where("id = ?", genre.ID).Updates(genre).Error; err != nil {
+	if err := r.DB.Model(&models.Genre{}).
+		Where("id = ?", genre.ID).
+		Select("name", "slug", "parent_id", "names").
+		Updates(genre).Error; err != nil {
+		if isUniqueViolation(err) {
+			return ErrDuplicateGenre
+		}
 		r.logger.Error("failed to update genre", slog.Any("id", genre.ID), slog.Any("error", err))
 		return err
 	}
@@ -90,3 +185,8 @@ func (r *gormGenreRepository) Delete(id uint) error {
 
 	return nil
 }
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
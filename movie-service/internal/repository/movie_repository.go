@@ -6,21 +6,64 @@ import (
 	"movie-service/internal/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+const searchRank = "ts_rank(movies.search_vector, websearch_to_tsquery('simple', ?))"
+
+// MovieFilter narrows Search. Zero values mean "any". SortBy is one of
+// "relevance", "title", "year", "duration" or "rating"; relevance needs
+// Query.
+type MovieFilter struct {
+	Query       string
+	GenreIDs    []uint
+	YearFrom    uint
+	YearTo      uint
+	AgeRatings  []constants.AgeRating
+	Status      constants.MovieStatus
+	DurationMin uint
+	DurationMax uint
+	PersonID    uint
+	Role        constants.CreditRole
+	SortBy      string
+	Desc        bool
+	After       *MovieCursor
+	Limit       int
+}
+
+// MovieCursor is the sort value and id of the last movie of a page.
+type MovieCursor struct {
+	Value any  `json:"v"`
+	ID    uint `json:"id"`
+}
+
 type MovieRepository interface {
 	Create(movie *models.Movie) error
 
 	List() ([]models.Movie, error)
 
+	Search(filter MovieFilter) ([]models.Movie, int64, error)
+
+	Rank(id uint, query string) (float64, error)
+
 	GetByID(id uint) (*models.Movie, error)
 
+	GetByExternalID(externalID string) (*models.Movie, error)
+
+	ListByIDs(ids []uint) ([]models.Movie, error)
+
 	GetNowShowing() ([]models.Movie, error)
 
 	GetComingSoon() ([]models.Movie, error)
 
 	Update(movie *models.Movie) error
 
+	UpdateColumns(movie *models.Movie, columns ...string) error
+
+	ListUnlockedStatuses() ([]models.Movie, error)
+
+	UpdateStatus(id uint, status constants.MovieStatus) error
+
 	Delete(id uint) error
 }
 
@@ -58,11 +101,114 @@ func (r *gormMovieRepository) List() ([]models.Movie, error) {
 
 }
 
+// Search returns one page of movies matching the filter together with the
+// total number of matches, ignoring the cursor and limit.
+func (r *gormMovieRepository) Search(filter MovieFilter) ([]models.Movie, int64, error) {
+	query := r.DB.Model(&models.Movie{})
+
+	if filter.Query != "" {
+		query = query.Where("movies.search_vector @@ websearch_to_tsquery('simple', ?)", filter.Query)
+	}
+	if len(filter.GenreIDs) > 0 {
+		// a parent genre also matches movies tagged with its sub-genres
+		query = query.Where("movies.id IN (SELECT movie_id FROM movie_genres WHERE genre_id IN ("+genreSubtree+"))", filter.GenreIDs)
+	}
+	if filter.YearFrom != 0 {
+		query = query.Where("movies.year >= ?", filter.YearFrom)
+	}
+	if filter.YearTo != 0 {
+		query = query.Where("movies.year <= ?", filter.YearTo)
+	}
+	if len(filter.AgeRatings) > 0 {
+		query = query.Where("movies.age_rating IN ?", filter.AgeRatings)
+	}
+	if filter.Status != "" {
+		query = query.Where("movies.movie_status = ?", filter.Status)
+	}
+	if filter.DurationMin != 0 {
+		query = query.Where("movies.duration >= ?", filter.DurationMin)
+	}
+	if filter.DurationMax != 0 {
+		query = query.Where("movies.duration <= ?", filter.DurationMax)
+	}
+	if filter.PersonID != 0 {
+		credits := r.DB.Model(&models.MovieCredit{}).Select("movie_id").Where("person_id = ?", filter.PersonID)
+		if filter.Role != "" {
+			credits = credits.Where("role = ?", filter.Role)
+		}
+		query = query.Where("movies.id IN (?)", credits)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		r.logger.Error("failed to count movies", slog.Any("error", err))
+		return nil, 0, err
+	}
+
+	key, vars := "movies.title", []any{}
+	switch filter.SortBy {
+	case "relevance":
+		key, vars = searchRank, []any{filter.Query}
+	case "year":
+		key = "movies.year"
+	case "duration":
+		key = "movies.duration"
+	case "rating":
+		key = "movies.rating_average"
+	}
+
+	direction, op := "ASC", ">"
+	if filter.Desc {
+		direction, op = "DESC", "<"
+	}
+
+	if filter.After != nil {
+		query = query.Where(
+			"("+key+", movies.id) "+op+" (?, ?)",
+			append(append([]any{}, vars...), filter.After.Value, filter.After.ID)...,
+		)
+	}
+
+	query = query.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:                key + " " + direction + ", movies.id " + direction,
+		Vars:               vars,
+		WithoutParentheses: true,
+	}})
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var movies []models.Movie
+	if err := query.Preload("Genres").Find(&movies).Error; err != nil {
+		r.logger.Error("failed to search movies", slog.Any("error", err))
+		return nil, 0, err
+	}
+
+	return movies, total, nil
+}
+
+// Rank returns the search rank of a movie for the query; it is needed to
+// build a relevance cursor.
+func (r *gormMovieRepository) Rank(id uint, query string) (float64, error) {
+	var rank float64
+	err := r.DB.Model(&models.Movie{}).
+		Select(searchRank, query).
+		Where("id = ?", id).
+		Scan(&rank).Error
+	return rank, err
+}
+
 func (r *gormMovieRepository) GetByID(id uint) (*models.Movie, error) {
 
 	var movie models.Movie
 
-	if err := r.DB.Preload("Genres").Where("id = ?", id).First(&movie).Error; err != nil {
+	if err := r.DB.
+		Preload("Genres").
+		Preload("Credits", func(db *gorm.DB) *gorm.DB { return db.Order("position, id") }).
+		Preload("Credits.Person").
+		Where("id = ?", id).
+		First(&movie).Error; err != nil {
 		r.logger.Error("failed to get movie by id", slog.Any("id", id), slog.Any("error", err))
 		return nil, err
 	}
@@ -70,6 +216,29 @@ func (r *gormMovieRepository) GetByID(id uint) (*models.Movie, error) {
 	return &movie, nil
 }
 
+func (r *gormMovieRepository) GetByExternalID(externalID string) (*models.Movie, error) {
+
+	var movie models.Movie
+
+	if err := r.DB.Preload("Genres").Where("external_id = ?", externalID).First(&movie).Error; err != nil {
+		return nil, err
+	}
+
+	return &movie, nil
+}
+
+func (r *gormMovieRepository) ListByIDs(ids []uint) ([]models.Movie, error) {
+
+	var movies []models.Movie
+
+	if err := r.DB.Preload("Genres").Where("id IN ?", ids).Find(&movies).Error; err != nil {
+		r.logger.Error("failed to list movies by ids", slog.Any("error", err))
+		return nil, err
+	}
+
+	return movies, nil
+}
+
 func (r *gormMovieRepository) GetNowShowing() ([]models.Movie, error) {
 
 	var movies []models.Movie
@@ -93,15 +262,68 @@ func (r *gormMovieRepository) GetComingSoon() ([]models.Movie, error) {
 	return movies, nil
 }
 
+// movieUpdateColumns are the columns Update writes. They are listed so empty
+// values and nil dates are written too; ratings and stored media keys are
+// maintained elsewhere.
+var movieUpdateColumns = []string{
+	"external_id", "title", "original_title", "description", "year", "duration",
+	"age_rating", "movie_status", "status_locked", "country", "languages", "subtitles",
+	"world_release_date", "local_release_date", "poster_url", "poster_sizes",
+	"backdrop_url", "backdrop_sizes", "trailer_url", "translations",
+}
+
+// Update writes the movie's fields and replaces its genres, so anything the
+// caller cleared is cleared in the database as well.
 func (r *gormMovieRepository) Update(movie *models.Movie) error {
 
-	if err := r.DB.Model(&models.Movie{}).Where("id = ?", movie.ID).Updates(movie).Error; err != nil {
-		r.logger.Error("failed to update movie", slog.Any("id", movie.ID), slog.Any("error", err))
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Movie{}).Select(movieUpdateColumns).Where("id = ?", movie.ID).Updates(movie).Error; err != nil {
+			r.logger.Error("failed to update movie", slog.Any("id", movie.ID), slog.Any("error", err))
+			return err
+		}
+
+		if err := tx.Model(movie).Association("Genres").Replace(movie.Genres); err != nil {
+			r.logger.Error("failed to update movie genres", slog.Any("id", movie.ID), slog.Any("error", err))
+			return err
+		}
+
+		return nil
+	})
+}
+
+// UpdateColumns writes only the given columns, including empty values, so a
+// field can be cleared.
+func (r *gormMovieRepository) UpdateColumns(movie *models.Movie, columns ...string) error {
+
+	if err := r.DB.Model(movie).Select(columns).Updates(movie).Error; err != nil {
+		r.logger.Error("failed to update movie columns", slog.Any("id", movie.ID), slog.Any("columns", columns), slog.Any("error", err))
 		return err
 	}
 
-	if err := r.DB.Model(movie).Association("Genres").Replace(movie.Genres); err != nil {
-		r.logger.Error("failed to update movie genres", slog.Any("id", movie.ID), slog.Any("error", err))
+	return nil
+}
+
+// ListUnlockedStatuses returns the movies whose status is derived
+// automatically, with only the fields needed to derive it.
+func (r *gormMovieRepository) ListUnlockedStatuses() ([]models.Movie, error) {
+
+	var movies []models.Movie
+
+	if err := r.DB.
+		Select("id", "movie_status", "world_release_date", "local_release_date").
+		Where("status_locked = ?", false).
+		Find(&movies).Error; err != nil {
+		r.logger.Error("failed to list movies for status refresh", slog.Any("error", err))
+		return nil, err
+	}
+
+	return movies, nil
+}
+
+func (r *gormMovieRepository) UpdateStatus(id uint, status constants.MovieStatus) error {
+
+	if err := r.DB.Model(&models.Movie{}).Where("id = ?", id).Update("movie_status", status).Error; err != nil {
+		r.logger.Error("failed to update movie status", slog.Any("id", id), slog.Any("error", err))
 		return err
 	}
 
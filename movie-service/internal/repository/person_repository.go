@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"log/slog"
+	"movie-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type PersonRepository interface {
+	Create(person *models.Person) error
+
+	List(name string) ([]models.Person, error)
+
+	GetByID(id uint) (*models.Person, error)
+
+	Update(person *models.Person) error
+
+	Delete(id uint) error
+}
+
+type gormPersonRepository struct {
+	DB     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewPersonRepository(db *gorm.DB, logger *slog.Logger) PersonRepository {
+	return &gormPersonRepository{
+		DB:     db,
+		logger: logger,
+	}
+}
+
+func (r *gormPersonRepository) Create(person *models.Person) error {
+	if err := r.DB.Create(person).Error; err != nil {
+		r.logger.Error("failed to create person", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
+
+// List returns people ordered by name, optionally only those whose name
+// contains the given text.
+func (r *gormPersonRepository) List(name string) ([]models.Person, error) {
+
+	var people []models.Person
+
+	query := r.DB.Order("name ASC, id ASC")
+	if name != "" {
+		query = query.Where("name ILIKE ?", "%"+name+"%")
+	}
+
+	if err := query.Find(&people).Error; err != nil {
+		r.logger.Error("failed to list people", slog.Any("error", err))
+		return nil, err
+	}
+
+	return people, nil
+}
+
+func (r *gormPersonRepository) GetByID(id uint) (*models.Person, error) {
+
+	var person models.Person
+
+	if err := r.DB.Where("id = ?", id).First(&person).Error; err != nil {
+		r.logger.Error("failed to get person by id", slog.Any("id", id), slog.Any("error", err))
+		return nil, err
+	}
+
+	return &person, nil
+}
+
+func (r *gormPersonRepository) Update(person *models.Person) error {
+
+	if err := r.DB.Model(&models.Person{}).Where("id = ?", person.ID).Updates(person).Error; err != nil {
+		r.logger.Error("failed to update person", slog.Any("id", person.ID), slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *gormPersonRepository) Delete(id uint) error {
+
+	res := r.DB.Delete(&models.Person{}, id)
+	if err := res.Error; err != nil {
+		r.logger.Error("failed to delete person", slog.Any("id", id), slog.Any("error", err))
+		return err
+	}
+
+	if res.RowsAffected == 0 {
+		r.logger.Info("person not found for delete", slog.Any("id", id))
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"errors"
+	"log/slog"
+	"movie-service/internal/constants"
+	"movie-service/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrDuplicateReview is returned when the user has already reviewed the movie.
+var ErrDuplicateReview = errors.New("movie already reviewed by this user")
+
+// ReviewFilter narrows List. Zero values mean "any". SortBy is "created_at"
+// or "rating".
+type ReviewFilter struct {
+	MovieID uint
+	UserID  uint
+	Status  constants.ReviewStatus
+	SortBy  string
+	Desc    bool
+	After   *ReviewCursor
+	Limit   int
+}
+
+// ReviewCursor is the sort value and id of the last review of a page.
+type ReviewCursor struct {
+	Value any  `json:"v"`
+	ID    uint `json:"id"`
+}
+
+type ReviewRepository interface {
+	Create(review *models.Review) error
+
+	GetByID(id uint) (*models.Review, error)
+
+	List(filter ReviewFilter) ([]models.Review, int64, error)
+
+	Update(review *models.Review) error
+
+	Delete(id uint) error
+
+	RefreshMovieRating(movieID uint) error
+}
+
+type gormReviewRepository struct {
+	DB     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewReviewRepository(db *gorm.DB, logger *slog.Logger) ReviewRepository {
+	return &gormReviewRepository{
+		DB:     db,
+		logger: logger,
+	}
+}
+
+func (r *gormReviewRepository) Create(review *models.Review) error {
+	if err := r.DB.Create(review).Error; err != nil {
+		if isUniqueViolation(err) {
+			return ErrDuplicateReview
+		}
+		r.logger.Error("failed to create review", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
+
+func (r *gormReviewRepository) GetByID(id uint) (*models.Review, error) {
+
+	var review models.Review
+
+	if err := r.DB.Where("id = ?", id).First(&review).Error; err != nil {
+		r.logger.Error("failed to get review by id", slog.Any("id", id), slog.Any("error", err))
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+// List returns one page of reviews matching the filter together with the
+// total number of matches, ignoring the cursor and limit.
+func (r *gormReviewRepository) List(filter ReviewFilter) ([]models.Review, int64, error) {
+	query := r.DB.Model(&models.Review{})
+
+	if filter.MovieID != 0 {
+		query = query.Where("movie_id = ?", filter.MovieID)
+	}
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		r.logger.Error("failed to count reviews", slog.Any("error", err))
+		return nil, 0, err
+	}
+
+	key := "created_at"
+	if filter.SortBy == "rating" {
+		key = "rating"
+	}
+
+	direction, op := "ASC", ">"
+	if filter.Desc {
+		direction, op = "DESC", "<"
+	}
+
+	if filter.After != nil {
+		query = query.Where("("+key+", id) "+op+" (?, ?)", filter.After.Value, filter.After.ID)
+	}
+
+	query = query.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:                key + " " + direction + ", id " + direction,
+		WithoutParentheses: true,
+	}})
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var reviews []models.Review
+	if err := query.Find(&reviews).Error; err != nil {
+		r.logger.Error("failed to list reviews", slog.Any("error", err))
+		return nil, 0, err
+	}
+
+	return reviews, total, nil
+}
+
+func (r *gormReviewRepository) Update(review *models.Review) error {
+
+	if err := r.DB.Model(review).
+		Select("rating", "text", "status", "moderation_note", "moderated_by", "moderated_at").
+		Updates(review).Error; err != nil {
+		r.logger.Error("failed to update review", slog.Any("id", review.ID), slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *gormReviewRepository) Delete(id uint) error {
+
+	res := r.DB.Delete(&models.Review{}, id)
+	if err := res.Error; err != nil {
+		r.logger.Error("failed to delete review", slog.Any("id", id), slog.Any("error", err))
+		return err
+	}
+
+	if res.RowsAffected == 0 {
+		r.logger.Info("review not found for delete", slog.Any("id", id))
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// RefreshMovieRating recomputes the movie's average rating and count from
+// its approved reviews.
+func (r *gormReviewRepository) RefreshMovieRating(movieID uint) error {
+
+	err := r.DB.Exec(`
+		UPDATE movies SET
+			rating_average = COALESCE(s.average, 0),
+			rating_count = s.count
+		FROM (
+			SELECT ROUND(AVG(rating)::numeric, 2) AS average, COUNT(*) AS count
+			FROM reviews
+			WHERE movie_id = ? AND status = ? AND deleted_at IS NULL
+		) s
+		WHERE movies.id = ?`,
+		movieID, constants.ReviewApproved, movieID,
+	).Error
+	if err != nil {
+		r.logger.Error("failed to refresh movie rating", slog.Any("movie_id", movieID), slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
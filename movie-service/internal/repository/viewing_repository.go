@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"log/slog"
+	"movie-service/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ViewingRepository interface {
+	Record(viewing *models.Viewing) error
+
+	HasViewed(userID, movieID uint) (bool, error)
+}
+
+type gormViewingRepository struct {
+	DB     *gorm.DB
+	logger *slog.Logger
+}
+
+func NewViewingRepository(db *gorm.DB, logger *slog.Logger) ViewingRepository {
+	return &gormViewingRepository{
+		DB:     db,
+		logger: logger,
+	}
+}
+
+// Record stores a viewing once per booking, so redelivered events are
+// harmless.
+func (r *gormViewingRepository) Record(viewing *models.Viewing) error {
+	if err := r.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(viewing).Error; err != nil {
+		r.logger.Error("failed to record viewing", slog.Any("booking_id", viewing.BookingID), slog.Any("error", err))
+		return err
+	}
+	return nil
+}
+
+func (r *gormViewingRepository) HasViewed(userID, movieID uint) (bool, error) {
+
+	var count int64
+
+	if err := r.DB.Model(&models.Viewing{}).
+		Where("user_id = ? AND movie_id = ?", userID, movieID).
+		Count(&count).Error; err != nil {
+		r.logger.Error("failed to check viewing", slog.Any("user_id", userID), slog.Any("movie_id", movieID), slog.Any("error", err))
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"movie-service/internal/dto"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownCatalogueFormat = errors.New("unknown catalogue format: use json or csv")
+	ErrInvalidCatalogue       = errors.New("catalogue could not be parsed")
+)
+
+// catalogueEntry is a parsed row with its position in the file, or the
+// reason it could not be read.
+type catalogueEntry struct {
+	line int
+	row  dto.CatalogueRow
+	err  error
+}
+
+// parseCatalogue reads a whole feed. Errors that make the file unreadable
+// are returned; problems with single rows are kept on the entry.
+func parseCatalogue(format string, r io.Reader) ([]catalogueEntry, error) {
+	switch strings.ToLower(format) {
+	case "json":
+		return parseCatalogueJSON(r)
+	case "csv":
+		return parseCatalogueCSV(r)
+	}
+	return nil, ErrUnknownCatalogueFormat
+}
+
+// parseCatalogueJSON accepts either an array of rows or {"movies": [...]}.
+func parseCatalogueJSON(r io.Reader) ([]catalogueEntry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []json.RawMessage
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var wrapper struct {
+			Movies []json.RawMessage `json:"movies"`
+		}
+		if err := json.Unmarshal(trimmed, &wrapper); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCatalogue, err)
+		}
+		raw = wrapper.Movies
+	} else if err := json.Unmarshal(trimmed, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCatalogue, err)
+	}
+
+	entries := make([]catalogueEntry, len(raw))
+	for i, item := range raw {
+		entries[i].line = i + 1
+		entries[i].err = json.Unmarshal(item, &entries[i].row)
+	}
+	return entries, nil
+}
+
+// parseCatalogueCSV maps columns by the header row, whose names match the
+// JSON field names. Unknown columns are ignored.
+func parseCatalogueCSV(r io.Reader) ([]catalogueEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCatalogue, err)
+	}
+	// spreadsheet exports often start with a byte order mark
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	if _, ok := columns["external_id"]; !ok {
+		return nil, fmt.Errorf("%w: missing external_id column", ErrInvalidCatalogue)
+	}
+
+	var entries []catalogueEntry
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// a broken quote can swallow the rest of the file, so stop here
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidCatalogue, line, err)
+		}
+
+		entry := catalogueEntry{line: line}
+		entry.row, entry.err = csvRow(columns, record)
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func csvRow(columns map[string]int, record []string) (dto.CatalogueRow, error) {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	list := func(name string) []string {
+		var values []string
+		for _, value := range strings.Split(field(name), "|") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+		return values
+	}
+	number := func(name string) (uint, error) {
+		value := field(name)
+		if value == "" {
+			return 0, nil
+		}
+		n, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %q is not a number", name, value)
+		}
+		return uint(n), nil
+	}
+
+	year, err := number("year")
+	if err != nil {
+		return dto.CatalogueRow{}, err
+	}
+	duration, err := number("duration")
+	if err != nil {
+		return dto.CatalogueRow{}, err
+	}
+
+	return dto.CatalogueRow{
+		ExternalID:  field("external_id"),
+		Title:       field("title"),
+		Description: field("description"),
+		Year:        year,
+		Duration:    duration,
+		AgeRating:   field("age_rating"),
+		Genres:      list("genres"),
+		MovieMetadata: dto.MovieMetadata{
+			OriginalTitle:    field("original_title"),
+			Country:          field("country"),
+			Languages:        list("languages"),
+			Subtitles:        list("subtitles"),
+			WorldReleaseDate: field("world_release_date"),
+			LocalReleaseDate: field("local_release_date"),
+			PosterURL:        field("poster_url"),
+			BackdropURL:      field("backdrop_url"),
+			TrailerURL:       field("trailer_url"),
+		},
+	}, nil
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"log/slog"
+	"movie-service/internal/constants"
+	"movie-service/internal/dto"
+	"movie-service/internal/models"
+	"movie-service/internal/repository"
+)
+
+type CreditService interface {
+	List(movieID uint) ([]models.MovieCredit, error)
+
+	Add(movieID uint, req *dto.CreditCreateRequest) (*models.MovieCredit, error)
+
+	Remove(movieID, creditID uint) error
+}
+
+type creditService struct {
+	repo       repository.CreditRepository
+	movieRepo  repository.MovieRepository
+	personRepo repository.PersonRepository
+	logger     *slog.Logger
+}
+
+func NewCreditService(
+	creditRepo repository.CreditRepository,
+	movieRepo repository.MovieRepository,
+	personRepo repository.PersonRepository,
+	logger *slog.Logger,
+) CreditService {
+	return &creditService{
+		repo:       creditRepo,
+		movieRepo:  movieRepo,
+		personRepo: personRepo,
+		logger:     logger,
+	}
+}
+
+func (s *creditService) List(movieID uint) ([]models.MovieCredit, error) {
+
+	if _, err := s.movieRepo.GetByID(movieID); err != nil {
+		s.logger.Error("credit list failed: get movie by id", slog.Any("movie_id", movieID), slog.Any("error", err))
+		return nil, err
+	}
+
+	credits, err := s.repo.ListByMovie(movieID)
+	if err != nil {
+		s.logger.Error("credit list failed", slog.Any("movie_id", movieID), slog.Any("error", err))
+		return nil, err
+	}
+
+	return credits, nil
+}
+
+func (s *creditService) Add(movieID uint, req *dto.CreditCreateRequest) (*models.MovieCredit, error) {
+
+	if _, err := s.movieRepo.GetByID(movieID); err != nil {
+		s.logger.Error("credit add failed: get movie by id", slog.Any("movie_id", movieID), slog.Any("error", err))
+		return nil, err
+	}
+
+	person, err := s.personRepo.GetByID(req.PersonID)
+	if err != nil {
+		s.logger.Error("credit add failed: get person by id", slog.Any("person_id", req.PersonID), slog.Any("error", err))
+		return nil, err
+	}
+
+	credit := models.MovieCredit{
+		MovieID:  movieID,
+		PersonID: person.ID,
+		Role:     req.Role,
+		Position: req.Position,
+	}
+	// only cast members play a character
+	if req.Role == constants.CreditActor {
+		credit.Character = req.Character
+	}
+
+	if err := s.repo.Create(&credit); err != nil {
+		s.logger.Error("credit add failed", slog.Any("movie_id", movieID), slog.Any("person_id", person.ID), slog.Any("error", err))
+		return nil, err
+	}
+
+	credit.Person = person
+	return &credit, nil
+}
+
+func (s *creditService) Remove(movieID, creditID uint) error {
+
+	if err := s.repo.Delete(movieID, creditID); err != nil {
+		s.logger.Error("credit remove failed", slog.Any("movie_id", movieID), slog.Any("id", creditID), slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
@@ -1,10 +1,22 @@
 package services
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
 	"movie-service/internal/dto"
 	"movie-service/internal/models"
 	"movie-service/internal/repository"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUnknownParentGenre = errors.New("parent genre not found")
+	ErrGenreCycle         = errors.New("a genre cannot be moved under itself or its sub-genres")
+	ErrGenreHasChildren   = errors.New("genre has sub-genres: move or delete them first")
 )
 
 type GenreService interface {
@@ -12,11 +24,20 @@ type GenreService interface {
 
 	List() ([]models.Genre, error)
 
+	// Tree returns the top-level genres with their sub-genres nested.
+	Tree() ([]models.Genre, error)
+
+	// GetByID and GetBySlug return the genre with its direct sub-genres.
 	GetByID(id uint) (*models.Genre, error)
 
+	GetBySlug(slug string) (*models.Genre, error)
+
 	Update(id uint, req *dto.GenreUpdateRequest) (*models.Genre, error)
 
 	Delete(id uint) error
+
+	// BackfillSlugs gives a slug to genres created before slugs existed.
+	BackfillSlugs() error
 }
 
 type genreService struct {
@@ -33,8 +54,25 @@ func NewGenreService(genreRepo repository.GenreRepository, logger *slog.Logger)
 
 func (s *genreService) Create(req *dto.GenreCreateRequest) (*models.Genre, error) {
 
+	// an empty slug is left for the repository to derive from the id
+	slug := req.Slug
+	if slug == "" {
+		slug = slugify(req.Name)
+	} else if !validSlug(slug) {
+		return nil, ErrInvalidSlug
+	}
+
 	genre := models.Genre{
-		Name: req.Name,
+		Name:  req.Name,
+		Slug:  slug,
+		Names: normalizeGenreNames(req.Names),
+	}
+
+	if req.ParentID != nil {
+		if err := s.checkParent(0, *req.ParentID); err != nil {
+			return nil, err
+		}
+		genre.ParentID = req.ParentID
 	}
 
 	if err := s.repo.Create(&genre); err != nil {
@@ -57,6 +95,38 @@ func (s *genreService) List() ([]models.Genre, error) {
 	return genres, nil
 }
 
+func (s *genreService) Tree() ([]models.Genre, error) {
+
+	genres, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	children := map[uint][]models.Genre{}
+	for _, genre := range genres {
+		if genre.ParentID != nil {
+			children[*genre.ParentID] = append(children[*genre.ParentID], genre)
+		}
+	}
+
+	var attach func(genre models.Genre) models.Genre
+	attach = func(genre models.Genre) models.Genre {
+		for _, child := range children[genre.ID] {
+			genre.Children = append(genre.Children, attach(child))
+		}
+		return genre
+	}
+
+	roots := []models.Genre{}
+	for _, genre := range genres {
+		if genre.ParentID == nil {
+			roots = append(roots, attach(genre))
+		}
+	}
+
+	return roots, nil
+}
+
 func (s *genreService) GetByID(id uint) (*models.Genre, error) {
 
 	genre, err := s.repo.GetByID(id)
@@ -66,6 +136,27 @@ func (s *genreService) GetByID(id uint) (*models.Genre, error) {
 		return nil, err
 	}
 
+	return s.withChildren(genre)
+}
+
+func (s *genreService) GetBySlug(slug string) (*models.Genre, error) {
+
+	genre, err := s.repo.GetBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withChildren(genre)
+}
+
+func (s *genreService) withChildren(genre *models.Genre) (*models.Genre, error) {
+
+	children, err := s.repo.ListChildren(genre.ID)
+	if err != nil {
+		return nil, err
+	}
+	genre.Children = children
+
 	return genre, nil
 }
 
@@ -81,6 +172,29 @@ func (s *genreService) Update(id uint, req *dto.GenreUpdateRequest) (*models.Gen
 		genre.Name = *req.Name
 	}
 
+	// the slug stays when the name changes so links keep working
+	if req.Slug != nil {
+		if !validSlug(*req.Slug) {
+			return nil, ErrInvalidSlug
+		}
+		genre.Slug = *req.Slug
+	}
+
+	if req.ParentID != nil {
+		if *req.ParentID == 0 {
+			genre.ParentID = nil
+		} else {
+			if err := s.checkParent(id, *req.ParentID); err != nil {
+				return nil, err
+			}
+			genre.ParentID = req.ParentID
+		}
+	}
+
+	if req.Names != nil {
+		genre.Names = normalizeGenreNames(req.Names)
+	}
+
 	if err := s.repo.Update(genre); err != nil {
 		s.logger.Error("genre update failed: update", slog.Any("id", genre.ID), slog.Any("error", err))
 		return nil, err
@@ -90,7 +204,15 @@ func (s *genreService) Update(id uint, req *dto.GenreUpdateRequest) (*models.Gen
 
 func (s *genreService) Delete(id uint) error {
 
-	err := s.repo.Delete(id)
+	children, err := s.repo.ListChildren(id)
+	if err != nil {
+		return err
+	}
+	if len(children) > 0 {
+		return ErrGenreHasChildren
+	}
+
+	err = s.repo.Delete(id)
 
 	if err != nil {
 		s.logger.Error("genre delete failed", slog.Any("id", id), slog.Any("error", err))
@@ -99,3 +221,76 @@ func (s *genreService) Delete(id uint) error {
 
 	return nil
 }
+
+func (s *genreService) BackfillSlugs() error {
+
+	genres, err := s.repo.List()
+	if err != nil {
+		return err
+	}
+
+	for i := range genres {
+		genre := &genres[i]
+		if genre.Slug != "" {
+			continue
+		}
+
+		genre.Slug = slugify(genre.Name)
+		if genre.Slug == "" {
+			genre.Slug = fmt.Sprintf("genre-%d", genre.ID)
+		}
+
+		err := s.repo.Update(genre)
+		if errors.Is(err, repository.ErrDuplicateGenre) {
+			genre.Slug = fmt.Sprintf("%s-%d", genre.Slug, genre.ID)
+			err = s.repo.Update(genre)
+		}
+		if err != nil {
+			return err
+		}
+
+		s.logger.Info("genre slug backfilled", slog.Any("id", genre.ID), slog.String("slug", genre.Slug))
+	}
+
+	return nil
+}
+
+// checkParent makes sure the parent exists and, for an existing genre, is
+// not the genre itself or one of its descendants.
+func (s *genreService) checkParent(id, parentID uint) error {
+
+	if _, err := s.repo.GetByID(parentID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUnknownParentGenre
+		}
+		return err
+	}
+
+	if id == 0 {
+		return nil
+	}
+
+	subtree, err := s.repo.SubtreeIDs([]uint{id})
+	if err != nil {
+		return err
+	}
+	if slices.Contains(subtree, parentID) {
+		return ErrGenreCycle
+	}
+
+	return nil
+}
+
+// normalizeGenreNames lower-cases the language keys so "RU" and "ru" are the
+// same translation.
+func normalizeGenreNames(names map[string]string) map[string]string {
+	if len(names) == 0 {
+		return nil
+	}
+
+	normalized := make(map[string]string, len(names))
+	for lang, name := range names {
+		normalized[strings.ToLower(strings.TrimSpace(lang))] = strings.TrimSpace(name)
+	}
+	return normalized
+}
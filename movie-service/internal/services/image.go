@@ -0,0 +1,93 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/jpeg"
+	"io"
+	"net/http"
+
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// maxImageSide and maxImagePixels guard against decompression bombs: a
+// small file can still declare a huge canvas. The pixel cap keeps a decoded
+// image at about 100MB.
+const (
+	maxImageSide   = 10000
+	maxImagePixels = 25_000_000
+)
+
+const thumbnailQuality = 85
+
+var (
+	ErrImageTooLarge    = errors.New("image is too large")
+	ErrUnsupportedImage = errors.New("unsupported image type: use jpeg, png or webp")
+	ErrInvalidImage     = errors.New("image could not be decoded")
+)
+
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// readImage reads at most maxSize bytes and sniffs the content type from the
+// data itself rather than trusting the client's header.
+func readImage(r io.Reader, maxSize int64) ([]byte, string, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, "", ErrImageTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if _, ok := imageExtensions[contentType]; !ok {
+		return nil, "", ErrUnsupportedImage
+	}
+
+	return data, contentType, nil
+}
+
+func decodeImage(data []byte) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if config.Width > maxImageSide || config.Height > maxImageSide || config.Width*config.Height > maxImagePixels {
+		return nil, ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	return img, nil
+}
+
+// thumbnail scales img to the given width, keeping the aspect ratio, and
+// encodes it as JPEG. Images narrower than width are not upscaled.
+func thumbnail(img image.Image, width int) ([]byte, error) {
+	bounds := img.Bounds()
+	if width > bounds.Dx() {
+		width = bounds.Dx()
+	}
+	height := max(1, bounds.Dy()*width/bounds.Dx())
+
+	// JPEG has no alpha, so transparent areas become white instead of black
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"movie-service/internal/constants"
+	"movie-service/internal/dto"
+	"movie-service/internal/models"
+	"movie-service/internal/repository"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"gorm.io/gorm"
+)
+
+const (
+	importCreated = "created"
+	importUpdated = "updated"
+	importFailed  = "error"
+)
+
+type ImportService interface {
+	// Import upserts the movies of a catalogue feed by external ID. A row
+	// that fails is reported and skipped; the other rows still go in.
+	Import(format string, r io.Reader, dryRun bool) (*dto.ImportReport, error)
+}
+
+type importService struct {
+	movieRepo repository.MovieRepository
+	genreRepo repository.GenreRepository
+	logger    *slog.Logger
+}
+
+func NewImportService(movieRepo repository.MovieRepository, genreRepo repository.GenreRepository, logger *slog.Logger) ImportService {
+	return &importService{
+		movieRepo: movieRepo,
+		genreRepo: genreRepo,
+		logger:    logger,
+	}
+}
+
+// catalogueImport holds the state of one run.
+type catalogueImport struct {
+	dryRun bool
+	report *dto.ImportReport
+	// genres caches lookups by lower-cased name; in a dry run it also holds
+	// the unsaved genres the feed would create
+	genres map[string]*models.Genre
+	// seen maps external IDs to the row that first used them
+	seen map[string]int
+}
+
+func (s *importService) Import(format string, r io.Reader, dryRun bool) (*dto.ImportReport, error) {
+
+	entries, err := parseCatalogue(format, r)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &catalogueImport{
+		dryRun: dryRun,
+		report: &dto.ImportReport{DryRun: dryRun, Total: len(entries), Rows: make([]dto.ImportRowResult, 0, len(entries))},
+		genres: map[string]*models.Genre{},
+		seen:   map[string]int{},
+	}
+
+	for _, entry := range entries {
+		result := dto.ImportRowResult{Row: entry.line, ExternalID: entry.row.ExternalID}
+
+		movie, action, err := s.importRow(run, entry)
+		if err != nil {
+			result.Action = importFailed
+			result.Error = err.Error()
+			run.report.Failed++
+		} else {
+			result.Action = action
+			result.MovieID = movie.ID
+			if action == importCreated {
+				run.report.Created++
+			} else {
+				run.report.Updated++
+			}
+		}
+
+		run.report.Rows = append(run.report.Rows, result)
+	}
+
+	s.logger.Info("catalogue imported",
+		slog.Bool("dry_run", dryRun),
+		slog.Int("total", run.report.Total),
+		slog.Int("created", run.report.Created),
+		slog.Int("updated", run.report.Updated),
+		slog.Int("failed", run.report.Failed),
+	)
+
+	return run.report, nil
+}
+
+func (s *importService) importRow(run *catalogueImport, entry catalogueEntry) (*models.Movie, string, error) {
+	if entry.err != nil {
+		return nil, "", entry.err
+	}
+
+	row := entry.row
+	if err := binding.Validator.ValidateStruct(&row); err != nil {
+		return nil, "", err
+	}
+
+	if first, ok := run.seen[row.ExternalID]; ok {
+		return nil, "", fmt.Errorf("external_id %q already used on row %d", row.ExternalID, first)
+	}
+	run.seen[row.ExternalID] = entry.line
+
+	rating, ok := constants.ParseAgeRating(row.AgeRating)
+	if !ok {
+		return nil, "", ErrInvalidAgeRating
+	}
+	worldRelease, err := parseDate(row.WorldReleaseDate)
+	if err != nil {
+		return nil, "", err
+	}
+	localRelease, err := parseDate(row.LocalReleaseDate)
+	if err != nil {
+		return nil, "", err
+	}
+
+	genres, err := s.resolveGenres(run, row.Genres)
+	if err != nil {
+		return nil, "", err
+	}
+
+	action := importUpdated
+	movie, err := s.movieRepo.GetByExternalID(row.ExternalID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		action = importCreated
+		// unlocked, so the status worker settles it from the release date
+		movie = &models.Movie{ExternalID: row.ExternalID, MovieStatus: constants.MovieComingSoon}
+	} else if err != nil {
+		return nil, "", err
+	}
+
+	movie.Title = row.Title
+	movie.OriginalTitle = row.OriginalTitle
+	movie.Description = row.Description
+	movie.Year = row.Year
+	movie.Duration = row.Duration
+	movie.AgeRating = rating
+	movie.Country = row.Country
+	movie.Languages = row.Languages
+	movie.Subtitles = row.Subtitles
+	movie.WorldReleaseDate = worldRelease
+	movie.LocalReleaseDate = localRelease
+	setMediaURL(movie, MediaPoster, row.PosterURL)
+	setMediaURL(movie, MediaBackdrop, row.BackdropURL)
+	movie.TrailerURL = row.TrailerURL
+	if row.Translations != nil {
+		movie.Translations = movieTexts(row.Translations)
+	}
+	movie.Genres = genres
+
+	if run.dryRun {
+		return movie, action, nil
+	}
+
+	if action == importCreated {
+		err = s.movieRepo.Create(movie)
+	} else {
+		err = s.movieRepo.Update(movie)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	return movie, action, nil
+}
+
+// resolveGenres finds genres by name and creates the missing ones.
+func (s *importService) resolveGenres(run *catalogueImport, names []string) ([]models.Genre, error) {
+	genres := make([]models.Genre, 0, len(names))
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+
+		genre, ok := run.genres[key]
+		if !ok {
+			found, err := s.genreRepo.GetByName(name)
+			switch {
+			case err == nil:
+				genre = found
+			case !errors.Is(err, gorm.ErrRecordNotFound):
+				return nil, err
+			default:
+				genre = &models.Genre{Name: name, Slug: slugify(name)}
+				if !run.dryRun {
+					if err := s.genreRepo.Create(genre); err != nil {
+						return nil, fmt.Errorf("create genre %q: %w", name, err)
+					}
+				}
+				run.report.NewGenres = append(run.report.NewGenres, name)
+			}
+			run.genres[key] = genre
+		}
+
+		genres = append(genres, *genre)
+	}
+
+	return genres, nil
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"movie-service/internal/models"
+	"movie-service/internal/repository"
+	"movie-service/internal/storage"
+	"strconv"
+	"time"
+)
+
+type MediaKind string
+
+const (
+	MediaPoster   MediaKind = "poster"
+	MediaBackdrop MediaKind = "backdrop"
+)
+
+// thumbnailWidths lists the generated sizes per kind; each one is exposed as
+// "w<width>" in the movie's *_sizes map.
+var thumbnailWidths = map[MediaKind][]int{
+	MediaPoster:   {185, 342, 780},
+	MediaBackdrop: {300, 780, 1280},
+}
+
+type MediaService interface {
+	Upload(ctx context.Context, movieID uint, kind MediaKind, file io.Reader) (*models.Movie, error)
+
+	Remove(ctx context.Context, movieID uint, kind MediaKind) (*models.Movie, error)
+}
+
+type mediaService struct {
+	repo          repository.MovieRepository
+	store         storage.BlobStore
+	maxUploadSize int64
+	logger        *slog.Logger
+}
+
+func NewMediaService(movieRepo repository.MovieRepository, store storage.BlobStore, maxUploadSize int64, logger *slog.Logger) MediaService {
+	return &mediaService{
+		repo:          movieRepo,
+		store:         store,
+		maxUploadSize: maxUploadSize,
+		logger:        logger,
+	}
+}
+
+// Upload stores the original image and its thumbnails under a fresh key
+// prefix, points the movie at them and then drops the previous files.
+func (s *mediaService) Upload(ctx context.Context, movieID uint, kind MediaKind, file io.Reader) (*models.Movie, error) {
+
+	movie, err := s.repo.GetByID(movieID)
+	if err != nil {
+		s.logger.Error("media upload failed: get movie by id", slog.Any("id", movieID), slog.Any("error", err))
+		return nil, err
+	}
+
+	data, contentType, err := readImage(file, s.maxUploadSize)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := decodeImage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// a new prefix per upload keeps URLs cacheable forever
+	prefix := fmt.Sprintf("movies/%d/%s/%s", movie.ID, kind, strconv.FormatInt(time.Now().UnixNano(), 36))
+
+	originalKey := prefix + "/original" + imageExtensions[contentType]
+	if err := s.store.Put(ctx, originalKey, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		s.logger.Error("media upload failed: store original", slog.Any("id", movieID), slog.Any("error", err))
+		return nil, err
+	}
+
+	sizes := make(map[string]string, len(thumbnailWidths[kind]))
+	for _, width := range thumbnailWidths[kind] {
+		thumb, err := thumbnail(img, width)
+		if err == nil {
+			name := "w" + strconv.Itoa(width)
+			key := prefix + "/" + name + ".jpg"
+			if err = s.store.Put(ctx, key, bytes.NewReader(thumb), int64(len(thumb)), "image/jpeg"); err == nil {
+				sizes[name] = s.store.URL(key)
+				continue
+			}
+		}
+
+		s.logger.Error("media upload failed: store thumbnail", slog.Any("id", movieID), slog.Int("width", width), slog.Any("error", err))
+		s.discard(ctx, prefix)
+		return nil, err
+	}
+
+	url, sizesField, key := mediaSlot(movie, kind)
+	oldPrefix := *key
+	*url, *sizesField, *key = s.store.URL(originalKey), sizes, prefix
+
+	if err := s.repo.UpdateColumns(movie, mediaColumns(kind)...); err != nil {
+		s.discard(ctx, prefix)
+		return nil, err
+	}
+
+	if oldPrefix != "" {
+		s.discard(ctx, oldPrefix)
+	}
+
+	return movie, nil
+}
+
+func (s *mediaService) Remove(ctx context.Context, movieID uint, kind MediaKind) (*models.Movie, error) {
+
+	movie, err := s.repo.GetByID(movieID)
+	if err != nil {
+		s.logger.Error("media remove failed: get movie by id", slog.Any("id", movieID), slog.Any("error", err))
+		return nil, err
+	}
+
+	url, sizes, key := mediaSlot(movie, kind)
+	oldPrefix := *key
+	*url, *sizes, *key = "", nil, ""
+
+	if err := s.repo.UpdateColumns(movie, mediaColumns(kind)...); err != nil {
+		return nil, err
+	}
+
+	if oldPrefix != "" {
+		s.discard(ctx, oldPrefix)
+	}
+
+	return movie, nil
+}
+
+// discard deletes stored files. Failures only leave orphaned objects behind,
+// so they are logged rather than returned.
+func (s *mediaService) discard(ctx context.Context, prefix string) {
+	if err := s.store.DeletePrefix(ctx, prefix); err != nil {
+		s.logger.Warn("failed to delete media", slog.String("prefix", prefix), slog.Any("error", err))
+	}
+}
+
+func mediaSlot(movie *models.Movie, kind MediaKind) (*string, *map[string]string, *string) {
+	if kind == MediaBackdrop {
+		return &movie.BackdropURL, &movie.BackdropSizes, &movie.BackdropKey
+	}
+	return &movie.PosterURL, &movie.PosterSizes, &movie.PosterKey
+}
+
+// setMediaURL points the movie at an image set by URL. Thumbnails of the
+// previous image would no longer match, so they are dropped; stored files
+// stay under the key until the next upload or removal discards them.
+func setMediaURL(movie *models.Movie, kind MediaKind, value string) {
+	url, sizes, _ := mediaSlot(movie, kind)
+	if *url == value {
+		return
+	}
+	// written as {} so the update clears them
+	*url, *sizes = value, map[string]string{}
+}
+
+func mediaColumns(kind MediaKind) []string {
+	return []string{string(kind) + "_url", string(kind) + "_sizes", string(kind) + "_key"}
+}
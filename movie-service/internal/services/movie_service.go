@@ -1,10 +1,30 @@
 package services
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	"math"
+	"movie-service/internal/constants"
 	"movie-service/internal/dto"
 	"movie-service/internal/models"
 	"movie-service/internal/repository"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const dateLayout = "2006-01-02"
+
+const defaultMoviePageSize = 20
+
+var (
+	ErrInvalidMovieQuery = errors.New("invalid movie query: check sort and cursor")
+	ErrInvalidAgeRating  = errors.New("invalid age rating: use 0+, 6+, 12+, 16+, 18+ or an MPAA rating")
+	ErrUnknownGenre      = errors.New("unknown genre")
 )
 
 type MovieService interface {
@@ -12,6 +32,8 @@ type MovieService interface {
 
 	List() ([]models.Movie, error)
 
+	Search(query dto.MovieListQuery) (*dto.MoviePage, error)
+
 	GetByID(id uint) (*models.Movie, error)
 
 	GetNowShowing() ([]models.Movie, error)
@@ -50,14 +72,44 @@ func (s *movieService) Create(req *dto.MovieCreateRequest) (*models.Movie, error
 		genres = append(genres, *genre)
 	}
 
+	ageRating, ok := constants.ParseAgeRating(req.AgeRating)
+	if !ok {
+		return nil, ErrInvalidAgeRating
+	}
+
+	worldRelease, err := parseDate(req.WorldReleaseDate)
+	if err != nil {
+		return nil, err
+	}
+	localRelease, err := parseDate(req.LocalReleaseDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// the status worker settles unlocked movies on its next run
+	if req.MovieStatus == "" {
+		req.MovieStatus = constants.MovieComingSoon
+	}
+
 	movie := models.Movie{
-		Title:       req.Title,
-		Description: req.Description,
-		Year:        req.Year,
-		Duration:    req.Duration,
-		AgeRating:   req.AgeRating,
-		MovieStatus: req.MovieStatus,
-		Genres:      genres,
+		Title:            req.Title,
+		OriginalTitle:    req.OriginalTitle,
+		Description:      req.Description,
+		Year:             req.Year,
+		Duration:         req.Duration,
+		AgeRating:        ageRating,
+		MovieStatus:      req.MovieStatus,
+		StatusLocked:     req.StatusLocked,
+		Country:          req.Country,
+		Languages:        req.Languages,
+		Subtitles:        req.Subtitles,
+		WorldReleaseDate: worldRelease,
+		LocalReleaseDate: localRelease,
+		PosterURL:        req.PosterURL,
+		BackdropURL:      req.BackdropURL,
+		TrailerURL:       req.TrailerURL,
+		Translations:     movieTexts(req.Translations),
+		Genres:           genres,
 	}
 
 	if err := s.repo.Create(&movie); err != nil {
@@ -80,6 +132,156 @@ func (s *movieService) List() ([]models.Movie, error) {
 	return movies, nil
 }
 
+func (s *movieService) Search(query dto.MovieListQuery) (*dto.MoviePage, error) {
+	filter := repository.MovieFilter{
+		Query:       query.Q,
+		GenreIDs:    query.GenreIDs,
+		YearFrom:    query.YearFrom,
+		YearTo:      query.YearTo,
+		Status:      constants.MovieStatus(query.Status),
+		DurationMin: query.DurationMin,
+		DurationMax: query.DurationMax,
+		PersonID:    query.PersonID,
+		Role:        constants.CreditRole(query.Role),
+		Limit:       query.Limit,
+	}
+
+	for _, slug := range query.Genres {
+		genre, err := s.genreRepo.GetBySlug(slug)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownGenre, slug)
+		}
+		if err != nil {
+			return nil, err
+		}
+		filter.GenreIDs = append(filter.GenreIDs, genre.ID)
+	}
+
+	for _, value := range query.AgeRatings {
+		rating, ok := constants.ParseAgeRating(value)
+		if !ok {
+			return nil, ErrInvalidAgeRating
+		}
+		filter.AgeRatings = append(filter.AgeRatings, rating)
+	}
+
+	sort := query.Sort
+	if sort == "" {
+		sort = "title"
+		if query.Q != "" {
+			sort = "relevance"
+		}
+	}
+	if sort == "relevance" {
+		if query.Q == "" {
+			return nil, ErrInvalidMovieQuery
+		}
+		// best matches first
+		filter.Desc = true
+	}
+	if len(sort) > 0 && sort[0] == '-' {
+		filter.Desc = true
+		sort = sort[1:]
+	}
+	filter.SortBy = sort
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultMoviePageSize
+	}
+
+	if query.Cursor != "" {
+		cursor, err := decodeMovieCursor(query.Cursor, filter.SortBy)
+		if err != nil {
+			return nil, ErrInvalidMovieQuery
+		}
+		filter.After = cursor
+	}
+
+	// one extra row tells whether another page exists
+	limit := filter.Limit
+	filter.Limit++
+
+	movies, total, err := s.repo.Search(filter)
+	if err != nil {
+		s.logger.Error("movie search failed", slog.Any("error", err))
+		return nil, err
+	}
+
+	page := &dto.MoviePage{Items: movies, Total: total}
+	if len(movies) > limit {
+		page.Items = movies[:limit]
+		last := page.Items[limit-1]
+
+		cursor := repository.MovieCursor{ID: last.ID}
+		switch filter.SortBy {
+		case "relevance":
+			if cursor.Value, err = s.repo.Rank(last.ID, query.Q); err != nil {
+				s.logger.Error("movie search failed: rank", slog.Any("error", err))
+				return nil, err
+			}
+		case "year":
+			cursor.Value = last.Year
+		case "duration":
+			cursor.Value = last.Duration
+		case "rating":
+			cursor.Value = last.RatingAverage
+		default:
+			cursor.Value = last.Title
+		}
+
+		if page.NextCursor, err = encodeMovieCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
+
+func encodeMovieCursor(cursor repository.MovieCursor) (string, error) {
+	raw, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodeMovieCursor checks the cursor value against the sort key, so a
+// cursor from a search with another sort is rejected instead of reaching
+// the query.
+func decodeMovieCursor(value, sortBy string) (*repository.MovieCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor repository.MovieCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return nil, err
+	}
+
+	switch v := cursor.Value.(type) {
+	case float64:
+		switch sortBy {
+		case "relevance", "rating":
+		case "year", "duration":
+			if v < 0 || v > math.MaxUint32 || v != math.Trunc(v) {
+				return nil, errors.New("cursor does not match sort")
+			}
+			cursor.Value = uint(v)
+		default:
+			return nil, errors.New("cursor does not match sort")
+		}
+	case string:
+		if sortBy != "title" {
+			return nil, errors.New("cursor does not match sort")
+		}
+	default:
+		return nil, errors.New("cursor without value")
+	}
+
+	return &cursor, nil
+}
+
 func (s *movieService) GetByID(id uint) (*models.Movie, error) {
 
 	movie, err := s.repo.GetByID(id)
@@ -124,12 +326,18 @@ func (s *movieService) Update(id uint, req *dto.MovieUpdateRequest) (*models.Mov
 		return nil, err
 	}
 
-	genre, err := s.genreRepo.GetByID(id)
-	if err != nil {
-		s.logger.Error("movie update failed: get genre by id", slog.Any("id", id), slog.Any("error", err))
-		return nil, err
+	if req.GenresID != nil {
+		genres := []models.Genre{}
+		for _, genreID := range *req.GenresID {
+			genre, err := s.genreRepo.GetByID(genreID)
+			if err != nil {
+				s.logger.Error("movie update failed: get genre by id", slog.Any("id", id), slog.Any("genre_id", genreID), slog.Any("error", err))
+				return nil, err
+			}
+			genres = append(genres, *genre)
+		}
+		movie.Genres = genres
 	}
-	movie.Genres = []models.Genre{*genre}
 
 	if req.Title != nil {
 		movie.Title = *req.Title
@@ -148,20 +356,92 @@ func (s *movieService) Update(id uint, req *dto.MovieUpdateRequest) (*models.Mov
 	}
 
 	if req.AgeRating != nil {
-		movie.AgeRating = *req.AgeRating
+		rating, ok := constants.ParseAgeRating(*req.AgeRating)
+		if !ok {
+			return nil, ErrInvalidAgeRating
+		}
+		movie.AgeRating = rating
 	}
 
 	if req.MovieStatus != nil {
 		movie.MovieStatus = *req.MovieStatus
 	}
 
+	if req.OriginalTitle != nil {
+		movie.OriginalTitle = *req.OriginalTitle
+	}
+
+	if req.Country != nil {
+		movie.Country = *req.Country
+	}
+
+	if req.Languages != nil {
+		movie.Languages = *req.Languages
+	}
+
+	if req.Subtitles != nil {
+		movie.Subtitles = *req.Subtitles
+	}
+
+	if req.WorldReleaseDate != nil {
+		if movie.WorldReleaseDate, err = parseDate(*req.WorldReleaseDate); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.LocalReleaseDate != nil {
+		if movie.LocalReleaseDate, err = parseDate(*req.LocalReleaseDate); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.PosterURL != nil {
+		setMediaURL(movie, MediaPoster, *req.PosterURL)
+	}
+
+	if req.BackdropURL != nil {
+		setMediaURL(movie, MediaBackdrop, *req.BackdropURL)
+	}
+
+	if req.TrailerURL != nil {
+		movie.TrailerURL = *req.TrailerURL
+	}
+
+	if req.Translations != nil {
+		movie.Translations = movieTexts(req.Translations)
+		if movie.Translations == nil {
+			// written as {} so the update clears them
+			movie.Translations = map[string]models.MovieText{}
+		}
+	}
+
 	if err := s.repo.Update(movie); err != nil {
 		s.logger.Error("movie update failed: update", slog.Any("id", movie.ID), slog.Any("error", err))
 		return nil, err
 	}
+
+	// Updates skips false, so the lock is written on its own
+	if req.StatusLocked != nil {
+		movie.StatusLocked = *req.StatusLocked
+		if err := s.repo.UpdateColumns(movie, "status_locked"); err != nil {
+			return nil, err
+		}
+	}
 	return movie, nil
 }
 
+// parseDate reads an optional "2006-01-02" date; an empty string means no date.
+func parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
+
 func (s *movieService) Delete(id uint) error {
 
 	err := s.repo.Delete(id)
@@ -173,3 +453,19 @@ func (s *movieService) Delete(id uint) error {
 
 	return nil
 }
+
+// movieTexts converts request translations, lower-casing the language codes.
+func movieTexts(translations map[string]dto.MovieTextRequest) map[string]models.MovieText {
+	if len(translations) == 0 {
+		return nil
+	}
+
+	texts := make(map[string]models.MovieText, len(translations))
+	for lang, text := range translations {
+		texts[strings.ToLower(strings.TrimSpace(lang))] = models.MovieText{
+			Title:       text.Title,
+			Description: text.Description,
+		}
+	}
+	return texts
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"log/slog"
+	"movie-service/internal/clients"
+	"movie-service/internal/constants"
+	"movie-service/internal/models"
+	"movie-service/internal/repository"
+	"time"
+)
+
+type MovieStatusService interface {
+	// Refresh recomputes the status of every unlocked movie from its release
+	// date and the cinema-service schedule.
+	Refresh() error
+}
+
+type movieStatusService struct {
+	repo       repository.MovieRepository
+	endedGrace time.Duration
+	logger     *slog.Logger
+}
+
+func NewMovieStatusService(movieRepo repository.MovieRepository, endedGrace time.Duration, logger *slog.Logger) MovieStatusService {
+	return &movieStatusService{
+		repo:       movieRepo,
+		endedGrace: endedGrace,
+		logger:     logger,
+	}
+}
+
+func (s *movieStatusService) Refresh() error {
+
+	summary, err := clients.GetScheduleSummary()
+	if err != nil {
+		s.logger.Error("movie status refresh failed: schedule summary", slog.Any("error", err))
+		return err
+	}
+
+	schedules := make(map[uint]clients.MovieSchedule, len(summary))
+	for _, schedule := range summary {
+		schedules[schedule.MovieID] = schedule
+	}
+
+	movies, err := s.repo.ListUnlockedStatuses()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	changed := 0
+
+	for _, movie := range movies {
+		var schedule *clients.MovieSchedule
+		if found, ok := schedules[movie.ID]; ok {
+			schedule = &found
+		}
+
+		status := deriveStatus(&movie, schedule, now, s.endedGrace)
+		if status == movie.MovieStatus {
+			continue
+		}
+
+		if err := s.repo.UpdateStatus(movie.ID, status); err != nil {
+			return err
+		}
+		s.logger.Info("movie status changed", slog.Any("id", movie.ID), slog.Any("from", movie.MovieStatus), slog.Any("to", status))
+		changed++
+	}
+
+	if changed > 0 {
+		s.logger.Info("movie statuses refreshed", slog.Int("changed", changed))
+	}
+
+	return nil
+}
+
+// deriveStatus decides where a movie is in its run:
+//   - coming_soon until it is released or its first session starts,
+//   - now_showing while sessions remain and for endedGrace after the last one,
+//   - ended afterwards. A released movie that was never scheduled ends
+//     endedGrace after its release.
+//
+// Without a schedule the release date alone cannot tell a movie is showing,
+// so the current status is kept unless the release is still ahead or long
+// past. That also leaves a movie without any dates where staff put it.
+func deriveStatus(movie *models.Movie, schedule *clients.MovieSchedule, now time.Time, endedGrace time.Duration) constants.MovieStatus {
+	release := movie.LocalReleaseDate
+	if release == nil {
+		release = movie.WorldReleaseDate
+	}
+	released := release != nil && !release.After(now)
+
+	if schedule == nil {
+		switch {
+		case release == nil:
+			return movie.MovieStatus
+		case !released:
+			return constants.MovieComingSoon
+		case now.Sub(*release) > endedGrace:
+			return constants.MovieEnded
+		}
+		return movie.MovieStatus
+	}
+
+	if !released && schedule.FirstStart.After(now) {
+		return constants.MovieComingSoon
+	}
+
+	if now.Sub(schedule.LastEnd) > endedGrace {
+		// preview screenings are over but the release is still ahead
+		if release != nil && release.After(now) {
+			return constants.MovieComingSoon
+		}
+		return constants.MovieEnded
+	}
+
+	return constants.MovieNowShowing
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"log/slog"
+	"movie-service/internal/constants"
+	"movie-service/internal/dto"
+	"movie-service/internal/models"
+	"movie-service/internal/repository"
+)
+
+type PersonService interface {
+	Create(req *dto.PersonCreateRequest) (*models.Person, error)
+
+	List(name string) ([]models.Person, error)
+
+	GetByID(id uint) (*models.Person, error)
+
+	Update(id uint, req *dto.PersonUpdateRequest) (*models.Person, error)
+
+	Delete(id uint) error
+
+	Filmography(id uint, role constants.CreditRole) ([]models.MovieCredit, error)
+}
+
+type personService struct {
+	repo       repository.PersonRepository
+	creditRepo repository.CreditRepository
+	logger     *slog.Logger
+}
+
+func NewPersonService(personRepo repository.PersonRepository, creditRepo repository.CreditRepository, logger *slog.Logger) PersonService {
+	return &personService{
+		repo:       personRepo,
+		creditRepo: creditRepo,
+		logger:     logger,
+	}
+}
+
+func (s *personService) Create(req *dto.PersonCreateRequest) (*models.Person, error) {
+
+	birthDate, err := parseDate(req.BirthDate)
+	if err != nil {
+		return nil, err
+	}
+
+	person := models.Person{
+		Name:      req.Name,
+		BirthDate: birthDate,
+		Country:   req.Country,
+		Bio:       req.Bio,
+		PhotoURL:  req.PhotoURL,
+	}
+
+	if err := s.repo.Create(&person); err != nil {
+		s.logger.Error("person create failed", slog.Any("error", err), slog.String("name", person.Name))
+		return nil, err
+	}
+
+	return &person, nil
+}
+
+func (s *personService) List(name string) ([]models.Person, error) {
+
+	people, err := s.repo.List(name)
+
+	if err != nil {
+		s.logger.Error("person list failed", slog.Any("error", err))
+		return nil, err
+	}
+
+	return people, nil
+}
+
+func (s *personService) GetByID(id uint) (*models.Person, error) {
+
+	person, err := s.repo.GetByID(id)
+
+	if err != nil {
+		s.logger.Error("person get by id failed", slog.Any("id", id), slog.Any("error", err))
+		return nil, err
+	}
+
+	return person, nil
+}
+
+func (s *personService) Update(id uint, req *dto.PersonUpdateRequest) (*models.Person, error) {
+
+	person, err := s.repo.GetByID(id)
+	if err != nil {
+		s.logger.Error("person update failed: get by id", slog.Any("id", id), slog.Any("error", err))
+		return nil, err
+	}
+
+	if req.Name != nil {
+		person.Name = *req.Name
+	}
+
+	if req.BirthDate != nil {
+		if person.BirthDate, err = parseDate(*req.BirthDate); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Country != nil {
+		person.Country = *req.Country
+	}
+
+	if req.Bio != nil {
+		person.Bio = *req.Bio
+	}
+
+	if req.PhotoURL != nil {
+		person.PhotoURL = *req.PhotoURL
+	}
+
+	if err := s.repo.Update(person); err != nil {
+		s.logger.Error("person update failed: update", slog.Any("id", person.ID), slog.Any("error", err))
+		return nil, err
+	}
+	return person, nil
+}
+
+func (s *personService) Delete(id uint) error {
+
+	err := s.repo.Delete(id)
+
+	if err != nil {
+		s.logger.Error("person delete failed", slog.Any("id", id), slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *personService) Filmography(id uint, role constants.CreditRole) ([]models.MovieCredit, error) {
+
+	if _, err := s.repo.GetByID(id); err != nil {
+		s.logger.Error("filmography failed: get person by id", slog.Any("id", id), slog.Any("error", err))
+		return nil, err
+	}
+
+	credits, err := s.creditRepo.ListByPerson(id, role)
+	if err != nil {
+		s.logger.Error("filmography failed", slog.Any("id", id), slog.Any("error", err))
+		return nil, err
+	}
+
+	return credits, nil
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"log/slog"
+	"math"
+	"movie-service/internal/dto"
+	"movie-service/internal/models"
+	"movie-service/internal/repository"
+	"sort"
+	"time"
+)
+
+const defaultRecommendationLimit = 10
+
+const (
+	ReasonGenres  = "genres"
+	ReasonPopular = "popular"
+)
+
+type RecommendationService interface {
+	Recommend(userID uint, limit int) ([]dto.Recommendation, error)
+
+	RecordBooking(booking *models.MovieBooking) error
+
+	ForgetBooking(bookingID uint) error
+}
+
+type recommendationService struct {
+	bookingRepo      repository.BookingRepository
+	movieRepo        repository.MovieRepository
+	popularityWindow time.Duration
+	logger           *slog.Logger
+}
+
+func NewRecommendationService(
+	bookingRepo repository.BookingRepository,
+	movieRepo repository.MovieRepository,
+	popularityWindow time.Duration,
+	logger *slog.Logger,
+) RecommendationService {
+	return &recommendationService{
+		bookingRepo:      bookingRepo,
+		movieRepo:        movieRepo,
+		popularityWindow: popularityWindow,
+		logger:           logger,
+	}
+}
+
+// Recommend ranks the now-showing movies the user has not booked by how
+// well their genres match the user's bookings. Movies without a match, and
+// every movie for a user without bookings, follow by popularity.
+func (s *recommendationService) Recommend(userID uint, limit int) ([]dto.Recommendation, error) {
+
+	if limit == 0 {
+		limit = defaultRecommendationLimit
+	}
+
+	candidates, err := s.movieRepo.GetNowShowing()
+	if err != nil {
+		s.logger.Error("recommend failed: now showing", slog.Any("user_id", userID), slog.Any("error", err))
+		return nil, err
+	}
+
+	popularity, err := s.bookingRepo.Popularity(time.Now().Add(-s.popularityWindow))
+	if err != nil {
+		s.logger.Error("recommend failed: popularity", slog.Any("user_id", userID), slog.Any("error", err))
+		return nil, err
+	}
+
+	bookedIDs, err := s.bookingRepo.MovieIDsByUser(userID)
+	if err != nil {
+		s.logger.Error("recommend failed: booked movies", slog.Any("user_id", userID), slog.Any("error", err))
+		return nil, err
+	}
+
+	affinity := map[uint]float64{}
+	booked := make(map[uint]bool, len(bookedIDs))
+	if len(bookedIDs) > 0 {
+		history, err := s.movieRepo.ListByIDs(bookedIDs)
+		if err != nil {
+			s.logger.Error("recommend failed: booked movie genres", slog.Any("user_id", userID), slog.Any("error", err))
+			return nil, err
+		}
+		for _, movie := range history {
+			booked[movie.ID] = true
+			for genreID := range genreSet(movie.Genres) {
+				affinity[genreID] += 1 / float64(len(history))
+			}
+		}
+	}
+
+	recommendations := make([]dto.Recommendation, 0, len(candidates))
+	for _, movie := range candidates {
+		if booked[movie.ID] {
+			continue
+		}
+
+		var score float64
+		for genreID := range genreSet(movie.Genres) {
+			score += affinity[genreID]
+		}
+
+		recommendation := dto.Recommendation{Movie: movie, Reason: ReasonPopular}
+		if score > 0 {
+			recommendation.Reason = ReasonGenres
+			recommendation.Score = math.Round(score*100) / 100
+		}
+		recommendations = append(recommendations, recommendation)
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		a, b := recommendations[i], recommendations[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if pa, pb := popularity[a.Movie.ID], popularity[b.Movie.ID]; pa != pb {
+			return pa > pb
+		}
+		if a.Movie.RatingAverage != b.Movie.RatingAverage {
+			return a.Movie.RatingAverage > b.Movie.RatingAverage
+		}
+		return a.Movie.ID < b.Movie.ID
+	})
+
+	if len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+
+	return recommendations, nil
+}
+
+func (s *recommendationService) RecordBooking(booking *models.MovieBooking) error {
+
+	if booking.UserID == 0 || booking.MovieID == 0 {
+		s.logger.Warn("booking without user or movie skipped", slog.Any("booking_id", booking.BookingID))
+		return nil
+	}
+	if booking.BookedAt.IsZero() {
+		booking.BookedAt = time.Now()
+	}
+
+	return s.bookingRepo.Record(booking)
+}
+
+func (s *recommendationService) ForgetBooking(bookingID uint) error {
+
+	if bookingID == 0 {
+		return nil
+	}
+
+	return s.bookingRepo.Remove(bookingID)
+}
+
+// genreSet is a movie's genres together with their parents, so a sub-genre
+// also counts as interest in the broader genre.
+func genreSet(genres []models.Genre) map[uint]struct{} {
+	set := make(map[uint]struct{}, len(genres)*2)
+	for _, genre := range genres {
+		set[genre.ID] = struct{}{}
+		if genre.ParentID != nil {
+			set[*genre.ParentID] = struct{}{}
+		}
+	}
+	return set
+}
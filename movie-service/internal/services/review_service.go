@@ -0,0 +1,350 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"movie-service/internal/constants"
+	"movie-service/internal/dto"
+	"movie-service/internal/models"
+	"movie-service/internal/repository"
+	"strings"
+	"time"
+)
+
+const defaultReviewPageSize = 20
+
+var (
+	ErrReviewNotAllowed   = errors.New("only viewers with a finished booking can review this movie")
+	ErrNotReviewAuthor    = errors.New("review belongs to another user")
+	ErrInvalidReviewQuery = errors.New("invalid review query: check sort and cursor")
+)
+
+type ReviewService interface {
+	ListForMovie(movieID uint, query dto.ReviewListQuery) (*dto.ReviewPage, error)
+
+	ListForModeration(query dto.ReviewModerationQuery) (*dto.ReviewPage, error)
+
+	Create(movieID, userID uint, req *dto.ReviewCreateRequest) (*models.Review, error)
+
+	Update(id, userID uint, req *dto.ReviewUpdateRequest) (*models.Review, error)
+
+	Delete(id, userID uint) error
+
+	Moderate(id, moderatorID uint, req *dto.ReviewModerationRequest) (*models.Review, error)
+
+	RecordViewing(viewing *models.Viewing) error
+}
+
+type reviewService struct {
+	repo        repository.ReviewRepository
+	movieRepo   repository.MovieRepository
+	viewingRepo repository.ViewingRepository
+	logger      *slog.Logger
+}
+
+func NewReviewService(
+	reviewRepo repository.ReviewRepository,
+	movieRepo repository.MovieRepository,
+	viewingRepo repository.ViewingRepository,
+	logger *slog.Logger,
+) ReviewService {
+	return &reviewService{
+		repo:        reviewRepo,
+		movieRepo:   movieRepo,
+		viewingRepo: viewingRepo,
+		logger:      logger,
+	}
+}
+
+func (s *reviewService) ListForMovie(movieID uint, query dto.ReviewListQuery) (*dto.ReviewPage, error) {
+
+	if _, err := s.movieRepo.GetByID(movieID); err != nil {
+		s.logger.Error("review list failed: get movie by id", slog.Any("movie_id", movieID), slog.Any("error", err))
+		return nil, err
+	}
+
+	filter := repository.ReviewFilter{
+		MovieID: movieID,
+		Status:  constants.ReviewApproved,
+		Limit:   query.Limit,
+	}
+
+	sort := query.Sort
+	if sort == "" {
+		sort = "-created_at"
+	}
+	if sort[0] == '-' {
+		filter.Desc = true
+		sort = sort[1:]
+	}
+	filter.SortBy = sort
+
+	return s.page(filter, query.Cursor)
+}
+
+func (s *reviewService) ListForModeration(query dto.ReviewModerationQuery) (*dto.ReviewPage, error) {
+
+	filter := repository.ReviewFilter{
+		MovieID: query.MovieID,
+		UserID:  query.UserID,
+		Status:  constants.ReviewStatus(query.Status),
+		SortBy:  "created_at",
+		Limit:   query.Limit,
+	}
+	if filter.Status == "" {
+		filter.Status = constants.ReviewPending
+	}
+
+	return s.page(filter, query.Cursor)
+}
+
+func (s *reviewService) page(filter repository.ReviewFilter, cursorValue string) (*dto.ReviewPage, error) {
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultReviewPageSize
+	}
+
+	if cursorValue != "" {
+		cursor, err := decodeReviewCursor(cursorValue, filter.SortBy)
+		if err != nil {
+			return nil, ErrInvalidReviewQuery
+		}
+		filter.After = cursor
+	}
+
+	// one extra row tells whether another page exists
+	limit := filter.Limit
+	filter.Limit++
+
+	reviews, total, err := s.repo.List(filter)
+	if err != nil {
+		s.logger.Error("review list failed", slog.Any("error", err))
+		return nil, err
+	}
+
+	page := &dto.ReviewPage{Items: reviews, Total: total}
+	if len(reviews) > limit {
+		page.Items = reviews[:limit]
+		last := page.Items[limit-1]
+
+		cursor := repository.ReviewCursor{Value: last.CreatedAt, ID: last.ID}
+		if filter.SortBy == "rating" {
+			cursor.Value = last.Rating
+		}
+
+		if page.NextCursor, err = encodeReviewCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
+
+func (s *reviewService) Create(movieID, userID uint, req *dto.ReviewCreateRequest) (*models.Review, error) {
+
+	if _, err := s.movieRepo.GetByID(movieID); err != nil {
+		s.logger.Error("review create failed: get movie by id", slog.Any("movie_id", movieID), slog.Any("error", err))
+		return nil, err
+	}
+
+	viewed, err := s.viewingRepo.HasViewed(userID, movieID)
+	if err != nil {
+		return nil, err
+	}
+	if !viewed {
+		s.logger.Warn("review by user without a finished booking", slog.Any("movie_id", movieID), slog.Any("user_id", userID))
+		return nil, ErrReviewNotAllowed
+	}
+
+	review := models.Review{
+		MovieID: movieID,
+		UserID:  userID,
+		Rating:  req.Rating,
+		Text:    strings.TrimSpace(req.Text),
+	}
+	review.Status = initialReviewStatus(review.Text)
+
+	if err := s.repo.Create(&review); err != nil {
+		s.logger.Error("review create failed", slog.Any("movie_id", movieID), slog.Any("user_id", userID), slog.Any("error", err))
+		return nil, err
+	}
+
+	if review.Status == constants.ReviewApproved {
+		s.refreshRating(movieID)
+	}
+
+	return &review, nil
+}
+
+func (s *reviewService) Update(id, userID uint, req *dto.ReviewUpdateRequest) (*models.Review, error) {
+
+	review, err := s.authoredReview(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	wasApproved := review.Status == constants.ReviewApproved
+
+	if req.Rating != nil {
+		review.Rating = *req.Rating
+	}
+	if req.Text != nil {
+		review.Text = strings.TrimSpace(*req.Text)
+	}
+
+	// an edited review has to be moderated again
+	review.Status = initialReviewStatus(review.Text)
+	review.ModerationNote = ""
+	review.ModeratedBy = nil
+	review.ModeratedAt = nil
+
+	if err := s.repo.Update(review); err != nil {
+		s.logger.Error("review update failed", slog.Any("id", id), slog.Any("error", err))
+		return nil, err
+	}
+
+	if wasApproved || review.Status == constants.ReviewApproved {
+		s.refreshRating(review.MovieID)
+	}
+
+	return review, nil
+}
+
+func (s *reviewService) Delete(id, userID uint) error {
+
+	review, err := s.authoredReview(id, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		s.logger.Error("review delete failed", slog.Any("id", id), slog.Any("error", err))
+		return err
+	}
+
+	if review.Status == constants.ReviewApproved {
+		s.refreshRating(review.MovieID)
+	}
+
+	return nil
+}
+
+func (s *reviewService) Moderate(id, moderatorID uint, req *dto.ReviewModerationRequest) (*models.Review, error) {
+
+	review, err := s.repo.GetByID(id)
+	if err != nil {
+		s.logger.Error("review moderate failed: get by id", slog.Any("id", id), slog.Any("error", err))
+		return nil, err
+	}
+
+	wasApproved := review.Status == constants.ReviewApproved
+	now := time.Now()
+
+	review.Status = constants.ReviewStatus(req.Status)
+	review.ModerationNote = strings.TrimSpace(req.Note)
+	review.ModeratedAt = &now
+	review.ModeratedBy = nil
+	if moderatorID != 0 {
+		review.ModeratedBy = &moderatorID
+	}
+
+	if err := s.repo.Update(review); err != nil {
+		s.logger.Error("review moderate failed", slog.Any("id", id), slog.Any("error", err))
+		return nil, err
+	}
+
+	s.logger.Info("review moderated", slog.Any("id", id), slog.Any("status", review.Status), slog.Any("moderator_id", moderatorID))
+
+	if wasApproved || review.Status == constants.ReviewApproved {
+		s.refreshRating(review.MovieID)
+	}
+
+	return review, nil
+}
+
+func (s *reviewService) RecordViewing(viewing *models.Viewing) error {
+
+	if viewing.UserID == 0 || viewing.MovieID == 0 {
+		s.logger.Warn("viewing without user or movie skipped", slog.Any("booking_id", viewing.BookingID))
+		return nil
+	}
+
+	return s.viewingRepo.Record(viewing)
+}
+
+func (s *reviewService) authoredReview(id, userID uint) (*models.Review, error) {
+
+	review, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if review.UserID != userID {
+		s.logger.Warn("review changed by another user", slog.Any("id", id), slog.Any("user_id", userID))
+		return nil, ErrNotReviewAuthor
+	}
+
+	return review, nil
+}
+
+// refreshRating keeps the movie's rating in step with its approved reviews.
+// A failure is logged; the review change stands and the next one catches up.
+func (s *reviewService) refreshRating(movieID uint) {
+	if err := s.repo.RefreshMovieRating(movieID); err != nil {
+		s.logger.Error("movie rating refresh failed", slog.Any("movie_id", movieID), slog.Any("error", err))
+	}
+}
+
+// initialReviewStatus publishes a bare rating at once; text waits for a
+// moderator.
+func initialReviewStatus(text string) constants.ReviewStatus {
+	if text == "" {
+		return constants.ReviewApproved
+	}
+	return constants.ReviewPending
+}
+
+func encodeReviewCursor(cursor repository.ReviewCursor) (string, error) {
+	raw, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodeReviewCursor restores the cursor value to the type of the sort
+// column, which JSON does not keep.
+func decodeReviewCursor(value, sortBy string) (*repository.ReviewCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor repository.ReviewCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return nil, err
+	}
+
+	switch v := cursor.Value.(type) {
+	case float64:
+		if sortBy != "rating" {
+			return nil, errors.New("cursor does not match sort")
+		}
+		cursor.Value = int(v)
+	case string:
+		if sortBy == "rating" {
+			return nil, errors.New("cursor does not match sort")
+		}
+		createdAt, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return nil, err
+		}
+		cursor.Value = createdAt
+	default:
+		return nil, errors.New("cursor without value")
+	}
+
+	return &cursor, nil
+}
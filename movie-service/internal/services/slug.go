@@ -0,0 +1,64 @@
+package services
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+const maxSlugLength = 100
+
+var ErrInvalidSlug = errors.New("invalid slug: use lowercase latin letters, digits and single hyphens")
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// cyrillic transliterates Russian letters so local genre names still get
+// readable slugs.
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "h", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "sch", 'ъ': "",
+	'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+}
+
+// slugify turns a name into a URL slug: "Sci-Fi & Fantasy" becomes
+// "sci-fi-fantasy". It returns "" when nothing usable is left.
+func slugify(name string) string {
+	var b strings.Builder
+	hyphen := false
+
+	for _, r := range strings.ToLower(name) {
+		var part string
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			part = string(r)
+		default:
+			part = cyrillic[r]
+		}
+
+		if part == "" {
+			// separators collapse into one hyphen; soft signs vanish
+			if r != 'ъ' && r != 'ь' {
+				hyphen = b.Len() > 0
+			}
+			continue
+		}
+
+		if hyphen {
+			b.WriteByte('-')
+			hyphen = false
+		}
+		b.WriteString(part)
+	}
+
+	slug := b.String()
+	if len(slug) > maxSlugLength {
+		slug = strings.TrimRight(slug[:maxSlugLength], "-")
+	}
+	return slug
+}
+
+func validSlug(slug string) bool {
+	return len(slug) <= maxSlugLength && slugPattern.MatchString(slug)
+}
@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStore keeps uploaded media. Keys are slash separated paths such as
+// "movies/12/poster/9f2c/w342.jpg".
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+
+	// DeletePrefix removes every object whose key starts with prefix.
+	DeletePrefix(ctx context.Context, prefix string) error
+
+	// URL returns the public address of the object stored under key.
+	URL(key string) string
+}
@@ -0,0 +1,128 @@
+package transport
+
+import (
+	"errors"
+	"log/slog"
+	"movie-service/internal/dto"
+	"movie-service/internal/repository"
+	"movie-service/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type CreditHandler struct {
+	service services.CreditService
+	logger  *slog.Logger
+}
+
+func NewCreditHandler(service services.CreditService, logger *slog.Logger) *CreditHandler {
+	return &CreditHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *CreditHandler) RegisterRoutes(ctx *gin.Engine) {
+	api := ctx.Group("/movies/:id/credits")
+	{
+		api.GET("/", h.List)
+		api.POST("/", h.Add)
+		api.DELETE("/:creditId", h.Remove)
+	}
+}
+
+func (h *CreditHandler) List(ctx *gin.Context) {
+
+	movieID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+	if err != nil {
+		h.logger.Error("invalid movie id param", slog.String("param", ctx.Param("id")), slog.Any("error", err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid movie id"})
+		return
+	}
+
+	credits, err := h.service.List(uint(movieID))
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "movie not found"})
+			return
+		}
+		h.logger.Error("failed to list credits", slog.Any("movie_id", movieID), slog.Any("error", err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list credits"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, credits)
+}
+
+func (h *CreditHandler) Add(ctx *gin.Context) {
+
+	movieID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+	if err != nil {
+		h.logger.Error("invalid movie id param", slog.String("param", ctx.Param("id")), slog.Any("error", err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid movie id"})
+		return
+	}
+
+	var req dto.CreditCreateRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("invalid create credit request", slog.Any("error", err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	credit, err := h.service.Add(uint(movieID), &req)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "movie or person not found"})
+			return
+		}
+		if errors.Is(err, repository.ErrDuplicateCredit) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.Error("credit add failed", slog.Any("movie_id", movieID), slog.Any("error", err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "credit create error"})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, credit)
+}
+
+func (h *CreditHandler) Remove(ctx *gin.Context) {
+
+	movieID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+	if err != nil {
+		h.logger.Error("invalid movie id param", slog.String("param", ctx.Param("id")), slog.Any("error", err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid movie id"})
+		return
+	}
+
+	creditID, err := strconv.ParseUint(ctx.Param("creditId"), 10, 64)
+
+	if err != nil {
+		h.logger.Error("invalid credit id param", slog.String("param", ctx.Param("creditId")), slog.Any("error", err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid credit id"})
+		return
+	}
+
+	if err := h.service.Remove(uint(movieID), uint(creditID)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "credit not found"})
+			return
+		}
+		h.logger.Error("failed to remove credit", slog.Any("id", creditID), slog.Any("error", err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove credit"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "credit removed successfully"})
+}
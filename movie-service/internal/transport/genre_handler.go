@@ -4,6 +4,8 @@ import (
 	"errors"
 	"log/slog"
 	"movie-service/internal/dto"
+	"movie-service/internal/models"
+	"movie-service/internal/repository"
 	"movie-service/internal/services"
 	"net/http"
 	"strconv"
@@ -13,14 +15,16 @@ import (
 )
 
 type GenreHandler struct {
-	service services.GenreService
-	logger  *slog.Logger
+	service         services.GenreService
+	catalogLanguage string
+	logger          *slog.Logger
 }
 
-func NewGenreHandler(service services.GenreService, logger *slog.Logger) *GenreHandler {
+func NewGenreHandler(service services.GenreService, catalogLanguage string, logger *slog.Logger) *GenreHandler {
 	return &GenreHandler{
-		service: service,
-		logger:  logger,
+		service:         service,
+		catalogLanguage: catalogLanguage,
+		logger:          logger,
 	}
 }
 
@@ -29,6 +33,7 @@ func (h *GenreHandler) RegisterRoutes(ctx *gin.Engine) {
 	{
 		api.POST("/", h.Create)
 		api.GET("/", h.List)
+		api.GET("/tree", h.Tree)
 		api.GET("/:id", h.GetByID)
 		api.PUT("/:id", h.Update)
 		api.DELETE("/:id", h.Delete)
@@ -49,6 +54,10 @@ func (h *GenreHandler) Create(ctx *gin.Context) {
 	genre, err := h.service.Create(&req)
 
 	if err != nil {
+		if status, ok := genreErrorStatus(err); ok {
+			ctx.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
 		h.logger.Error("genre create failed", slog.Any("error", err), slog.String("name", req.Name))
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "genre create error"})
 		return
@@ -66,31 +75,57 @@ func (h *GenreHandler) List(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "genre list error"})
 		return
 	}
+	langs := contentLanguages(ctx, h.catalogLanguage)
+	for i := range genres {
+		genres[i].Localize(langs)
+	}
+
 	ctx.JSON(http.StatusOK, genres)
 }
 
-func (h *GenreHandler) GetByID(ctx *gin.Context) {
+func (h *GenreHandler) Tree(ctx *gin.Context) {
 
-	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	genres, err := h.service.Tree()
 
 	if err != nil {
-		h.logger.Error("invalid genre id param", slog.String("param", ctx.Param("id")), slog.Any("error", err))
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid genre id"})
+		h.logger.Error("genre tree handler failed", slog.Any("error", err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "genre list error"})
 		return
 	}
+	langs := contentLanguages(ctx, h.catalogLanguage)
+	for i := range genres {
+		genres[i].Localize(langs)
+	}
+
+	ctx.JSON(http.StatusOK, genres)
+}
+
+// GetByID also accepts a slug in place of the id.
+func (h *GenreHandler) GetByID(ctx *gin.Context) {
+
+	var genre *models.Genre
+	param := ctx.Param("id")
+
+	id, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		genre, err = h.service.GetBySlug(param)
+	} else {
+		genre, err = h.service.GetByID(uint(id))
+	}
 
-	genre, err := h.service.GetByID(uint(id))
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			h.logger.Info("genre not found", slog.Any("id", id))
+			h.logger.Info("genre not found", slog.String("param", param))
 			ctx.JSON(http.StatusNotFound, gin.H{"error": "genre not found"})
 			return
 		}
-		h.logger.Error("failed to get genre", slog.Any("id", id), slog.Any("error", err))
+		h.logger.Error("failed to get genre", slog.String("param", param), slog.Any("error", err))
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get genre"})
 		return
 	}
 
+	genre.Localize(contentLanguages(ctx, h.catalogLanguage))
+
 	ctx.JSON(http.StatusOK, genre)
 }
 
@@ -120,6 +155,10 @@ func (h *GenreHandler) Update(ctx *gin.Context) {
 			ctx.JSON(http.StatusNotFound, gin.H{"error": "genre not found"})
 			return
 		}
+		if status, ok := genreErrorStatus(err); ok {
+			ctx.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
 		h.logger.Error("genre update failed", slog.Any("id", id), slog.Any("error", err))
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -144,6 +183,10 @@ func (h *GenreHandler) Delete(ctx *gin.Context) {
 			ctx.JSON(http.StatusNotFound, gin.H{"error": "genre not found"})
 			return
 		}
+		if errors.Is(err, services.ErrGenreHasChildren) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		h.logger.Error("failed to delete genre", slog.Any("id", id), slog.Any("error", err))
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete genre"})
 		return
@@ -151,3 +194,16 @@ func (h *GenreHandler) Delete(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "genre deleted successfully"})
 }
+
+// genreErrorStatus maps the validation errors of create and update.
+func genreErrorStatus(err error) (int, bool) {
+	switch {
+	case errors.Is(err, repository.ErrDuplicateGenre):
+		return http.StatusConflict, true
+	case errors.Is(err, services.ErrInvalidSlug),
+		errors.Is(err, services.ErrUnknownParentGenre),
+		errors.Is(err, services.ErrGenreCycle):
+		return http.StatusBadRequest, true
+	}
+	return 0, false
+}
@@ -0,0 +1,117 @@
+package transport
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"movie-service/internal/services"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ImportHandler struct {
+	service       services.ImportService
+	maxImportSize int64
+	logger        *slog.Logger
+}
+
+func NewImportHandler(service services.ImportService, maxImportSize int64, logger *slog.Logger) *ImportHandler {
+	return &ImportHandler{
+		service:       service,
+		maxImportSize: maxImportSize,
+		logger:        logger,
+	}
+}
+
+func (h *ImportHandler) RegisterRoutes(ctx *gin.Engine) {
+	api := ctx.Group("/movies")
+	{
+		api.POST("/import", h.Import)
+	}
+}
+
+// Import takes the catalogue either as a multipart "file" field or as the raw
+// request body. The format comes from ?format=, then the file extension, then
+// the Content-Type. ?dry_run=true reports what would change without saving.
+func (h *ImportHandler) Import(ctx *gin.Context) {
+
+	dryRun, err := strconv.ParseBool(ctx.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid dry_run"})
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, h.maxImportSize+multipartOverhead)
+
+	format := ctx.Query("format")
+	var body io.Reader = ctx.Request.Body
+
+	if strings.HasPrefix(ctx.ContentType(), "multipart/") {
+		header, err := ctx.FormFile("file")
+		if err != nil {
+			if isTooLarge(err) {
+				ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "catalogue file is too large"})
+				return
+			}
+			h.logger.Info("invalid catalogue upload", slog.Any("error", err))
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "multipart field \"file\" is required"})
+			return
+		}
+
+		file, err := header.Open()
+		if err != nil {
+			h.logger.Error("failed to open catalogue file", slog.Any("error", err))
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to read uploaded file"})
+			return
+		}
+		defer file.Close()
+
+		body = file
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+		}
+		if format == "" {
+			format = formatFromContentType(header.Header.Get("Content-Type"))
+		}
+	} else if format == "" {
+		format = formatFromContentType(ctx.ContentType())
+	}
+
+	report, err := h.service.Import(format, body, dryRun)
+
+	if err != nil {
+		switch {
+		case isTooLarge(err):
+			ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "catalogue file is too large"})
+		case errors.Is(err, services.ErrUnknownCatalogueFormat), errors.Is(err, services.ErrInvalidCatalogue):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			h.logger.Error("catalogue import failed", slog.String("format", format), slog.Any("error", err))
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "catalogue import error"})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+func formatFromContentType(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/json":
+		return "json"
+	case "text/csv", "application/csv":
+		return "csv"
+	}
+	return ""
+}
+
+func isTooLarge(err error) bool {
+	var tooLarge *http.MaxBytesError
+	return errors.As(err, &tooLarge)
+}
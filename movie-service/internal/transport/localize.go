@@ -0,0 +1,19 @@
+package transport
+
+import (
+	"shared/i18n"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// contentLanguages lists the translations to try for the client: the
+// accepted languages up to the catalogue's own language, whose text is
+// already in the main fields.
+func contentLanguages(ctx *gin.Context, catalogLanguage string) []string {
+	langs := i18n.Languages(ctx)
+	if i := slices.Index(langs, catalogLanguage); i >= 0 {
+		return langs[:i]
+	}
+	return langs
+}
@@ -0,0 +1,129 @@
+package transport
+
+import (
+	"errors"
+	"log/slog"
+	"movie-service/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// multipartOverhead leaves room for the form boundaries and headers around
+// the file itself.
+const multipartOverhead = 1 << 20
+
+type MediaHandler struct {
+	service       services.MediaService
+	maxUploadSize int64
+	logger        *slog.Logger
+}
+
+func NewMediaHandler(service services.MediaService, maxUploadSize int64, logger *slog.Logger) *MediaHandler {
+	return &MediaHandler{
+		service:       service,
+		maxUploadSize: maxUploadSize,
+		logger:        logger,
+	}
+}
+
+func (h *MediaHandler) RegisterRoutes(ctx *gin.Engine) {
+	api := ctx.Group("/movies")
+	{
+		api.PUT("/:id/poster", h.Upload(services.MediaPoster))
+		api.DELETE("/:id/poster", h.Remove(services.MediaPoster))
+		api.PUT("/:id/backdrop", h.Upload(services.MediaBackdrop))
+		api.DELETE("/:id/backdrop", h.Remove(services.MediaBackdrop))
+	}
+}
+
+// Upload expects a multipart form with the image in the "file" field.
+func (h *MediaHandler) Upload(kind services.MediaKind) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+
+		id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+		if err != nil {
+			h.logger.Error("invalid movie id param", slog.String("param", ctx.Param("id")), slog.Any("error", err))
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid movie id"})
+			return
+		}
+
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, h.maxUploadSize+multipartOverhead)
+
+		header, err := ctx.FormFile("file")
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": services.ErrImageTooLarge.Error()})
+				return
+			}
+			h.logger.Info("invalid media upload", slog.Any("id", id), slog.Any("error", err))
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "multipart field \"file\" is required"})
+			return
+		}
+
+		if header.Size > h.maxUploadSize {
+			ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": services.ErrImageTooLarge.Error()})
+			return
+		}
+
+		file, err := header.Open()
+		if err != nil {
+			h.logger.Error("failed to open uploaded file", slog.Any("id", id), slog.Any("error", err))
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to read uploaded file"})
+			return
+		}
+		defer file.Close()
+
+		movie, err := h.service.Upload(ctx.Request.Context(), uint(id), kind, file)
+
+		if err != nil {
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				ctx.JSON(http.StatusNotFound, gin.H{"error": "movie not found"})
+			case errors.Is(err, services.ErrImageTooLarge):
+				ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+			case errors.Is(err, services.ErrUnsupportedImage):
+				ctx.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+			case errors.Is(err, services.ErrInvalidImage):
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			default:
+				h.logger.Error("media upload failed", slog.Any("id", id), slog.String("kind", string(kind)), slog.Any("error", err))
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "media upload error"})
+			}
+			return
+		}
+
+		ctx.JSON(http.StatusOK, movie)
+	}
+}
+
+func (h *MediaHandler) Remove(kind services.MediaKind) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+
+		id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+		if err != nil {
+			h.logger.Error("invalid movie id param", slog.String("param", ctx.Param("id")), slog.Any("error", err))
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid movie id"})
+			return
+		}
+
+		movie, err := h.service.Remove(ctx.Request.Context(), uint(id), kind)
+
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				ctx.JSON(http.StatusNotFound, gin.H{"error": "movie not found"})
+				return
+			}
+			h.logger.Error("media remove failed", slog.Any("id", id), slog.String("kind", string(kind)), slog.Any("error", err))
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "media remove error"})
+			return
+		}
+
+		ctx.JSON(http.StatusOK, movie)
+	}
+}
@@ -13,14 +13,16 @@ import (
 )
 
 type MovieHandler struct {
-	service services.MovieService
-	logger  *slog.Logger
+	service         services.MovieService
+	catalogLanguage string
+	logger          *slog.Logger
 }
 
-func NewMovieHandler(service services.MovieService, logger *slog.Logger) *MovieHandler {
+func NewMovieHandler(service services.MovieService, catalogLanguage string, logger *slog.Logger) *MovieHandler {
 	return &MovieHandler{
-		service: service,
-		logger:  logger,
+		service:         service,
+		catalogLanguage: catalogLanguage,
+		logger:          logger,
 	}
 }
 
@@ -51,6 +53,10 @@ func (h *MovieHandler) Create(ctx *gin.Context) {
 	movie, err := h.service.Create(&req)
 
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidAgeRating) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		h.logger.Error("movie create failed", slog.Any("error", err), slog.String("title", req.Title))
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "movie create error"})
 		return
@@ -61,14 +67,31 @@ func (h *MovieHandler) Create(ctx *gin.Context) {
 
 func (h *MovieHandler) List(ctx *gin.Context) {
 
-	movies, err := h.service.List()
+	var query dto.MovieListQuery
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		h.logger.Info("invalid movie list query", slog.Any("error", err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.service.Search(query)
 
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidMovieQuery) || errors.Is(err, services.ErrInvalidAgeRating) || errors.Is(err, services.ErrUnknownGenre) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		h.logger.Error("movie list handler failed", slog.Any("error", err))
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "movie list error"})
 		return
 	}
-	ctx.JSON(http.StatusOK, movies)
+	langs := contentLanguages(ctx, h.catalogLanguage)
+	for i := range page.Items {
+		page.Items[i].Localize(langs)
+	}
+
+	ctx.JSON(http.StatusOK, page)
 }
 
 func (h *MovieHandler) GetByID(ctx *gin.Context) {
@@ -95,6 +118,8 @@ func (h *MovieHandler) GetByID(ctx *gin.Context) {
 		return
 	}
 
+	movie.Localize(contentLanguages(ctx, h.catalogLanguage))
+
 	ctx.JSON(http.StatusOK, movie)
 }
 
@@ -109,6 +134,11 @@ func (h *MovieHandler) NowShowing(ctx *gin.Context) {
 		return
 	}
 
+	langs := contentLanguages(ctx, h.catalogLanguage)
+	for i := range movies {
+		movies[i].Localize(langs)
+	}
+
 	ctx.JSON(http.StatusOK, movies)
 
 }
@@ -123,6 +153,11 @@ func (h *MovieHandler) ComingSoon(ctx *gin.Context) {
 		return
 	}
 
+	langs := contentLanguages(ctx, h.catalogLanguage)
+	for i := range movies {
+		movies[i].Localize(langs)
+	}
+
 	ctx.JSON(http.StatusOK, movies)
 
 }
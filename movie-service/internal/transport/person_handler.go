@@ -0,0 +1,199 @@
+package transport
+
+import (
+	"errors"
+	"log/slog"
+	"movie-service/internal/constants"
+	"movie-service/internal/dto"
+	"movie-service/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type PersonHandler struct {
+	service         services.PersonService
+	catalogLanguage string
+	logger          *slog.Logger
+}
+
+func NewPersonHandler(service services.PersonService, catalogLanguage string, logger *slog.Logger) *PersonHandler {
+	return &PersonHandler{
+		service:         service,
+		catalogLanguage: catalogLanguage,
+		logger:          logger,
+	}
+}
+
+func (h *PersonHandler) RegisterRoutes(ctx *gin.Engine) {
+	api := ctx.Group("/people")
+	{
+		api.POST("/", h.Create)
+		api.GET("/", h.List)
+		api.GET("/:id", h.GetByID)
+		api.GET("/:id/movies", h.Filmography)
+		api.PUT("/:id", h.Update)
+		api.DELETE("/:id", h.Delete)
+	}
+}
+
+func (h *PersonHandler) Create(ctx *gin.Context) {
+
+	var req dto.PersonCreateRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("invalid create person request", slog.Any("error", err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	person, err := h.service.Create(&req)
+
+	if err != nil {
+		h.logger.Error("person create failed", slog.Any("error", err), slog.String("name", req.Name))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "person create error"})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, person)
+}
+
+func (h *PersonHandler) List(ctx *gin.Context) {
+
+	people, err := h.service.List(ctx.Query("q"))
+
+	if err != nil {
+		h.logger.Error("person list handler failed", slog.Any("error", err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "person list error"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, people)
+}
+
+func (h *PersonHandler) GetByID(ctx *gin.Context) {
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+	if err != nil {
+		h.logger.Error("invalid person id param", slog.String("param", ctx.Param("id")), slog.Any("error", err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid person id"})
+		return
+	}
+
+	person, err := h.service.GetByID(uint(id))
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			h.logger.Info("person not found", slog.Any("id", id))
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "person not found"})
+			return
+		}
+
+		h.logger.Error("failed to get person", slog.Any("id", id), slog.Any("error", err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get person"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, person)
+}
+
+func (h *PersonHandler) Filmography(ctx *gin.Context) {
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+	if err != nil {
+		h.logger.Error("invalid person id param", slog.String("param", ctx.Param("id")), slog.Any("error", err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid person id"})
+		return
+	}
+
+	role := constants.CreditRole(ctx.Query("role"))
+	if role != "" && !role.Valid() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid role"})
+		return
+	}
+
+	credits, err := h.service.Filmography(uint(id), role)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			h.logger.Info("person not found", slog.Any("id", id))
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "person not found"})
+			return
+		}
+
+		h.logger.Error("failed to get filmography", slog.Any("id", id), slog.Any("error", err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get filmography"})
+		return
+	}
+
+	langs := contentLanguages(ctx, h.catalogLanguage)
+	for _, credit := range credits {
+		if credit.Movie != nil {
+			credit.Movie.Localize(langs)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, credits)
+}
+
+func (h *PersonHandler) Update(ctx *gin.Context) {
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+	if err != nil {
+		h.logger.Error("invalid person id param", slog.String("param", ctx.Param("id")), slog.Any("error", err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid person id"})
+		return
+	}
+
+	var req dto.PersonUpdateRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("invalid update person request", slog.Any("error", err), slog.Any("id", id))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	person, err := h.service.Update(uint(id), &req)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			h.logger.Info("person not found for update", slog.Any("id", id))
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "person not found"})
+			return
+		}
+		h.logger.Error("person update failed", slog.Any("id", id), slog.Any("error", err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, person)
+}
+
+func (h *PersonHandler) Delete(ctx *gin.Context) {
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+	if err != nil {
+		h.logger.Error("invalid person id param", slog.String("param", ctx.Param("id")), slog.Any("error", err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid person id"})
+		return
+	}
+
+	if err := h.service.Delete(uint(id)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			h.logger.Info("person not found for delete", slog.Any("id", id))
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "person not found"})
+			return
+		}
+		h.logger.Error("failed to delete person", slog.Any("id", id), slog.Any("error", err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete person"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "person deleted successfully"})
+}
@@ -0,0 +1,61 @@
+package transport
+
+import (
+	"log/slog"
+	"movie-service/internal/dto"
+	"movie-service/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecommendationHandler struct {
+	service         services.RecommendationService
+	catalogLanguage string
+	logger          *slog.Logger
+}
+
+func NewRecommendationHandler(service services.RecommendationService, catalogLanguage string, logger *slog.Logger) *RecommendationHandler {
+	return &RecommendationHandler{
+		service:         service,
+		catalogLanguage: catalogLanguage,
+		logger:          logger,
+	}
+}
+
+func (h *RecommendationHandler) RegisterRoutes(ctx *gin.Engine) {
+	api := ctx.Group("/me")
+	{
+		api.GET("/recommendations", h.Recommend)
+	}
+}
+
+func (h *RecommendationHandler) Recommend(ctx *gin.Context) {
+
+	userID, ok := requestUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var query dto.RecommendationQuery
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	recommendations, err := h.service.Recommend(userID, query.Limit)
+
+	if err != nil {
+		h.logger.Error("failed to get recommendations", slog.Any("user_id", userID), slog.Any("error", err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get recommendations"})
+		return
+	}
+
+	langs := contentLanguages(ctx, h.catalogLanguage)
+	for i := range recommendations {
+		recommendations[i].Movie.Localize(langs)
+	}
+
+	ctx.JSON(http.StatusOK, recommendations)
+}
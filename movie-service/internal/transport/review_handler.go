@@ -0,0 +1,280 @@
+package transport
+
+import (
+	"errors"
+	"log/slog"
+	"movie-service/internal/dto"
+	"movie-service/internal/repository"
+	"movie-service/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// userIDHeader carries the id of the authenticated user; the gateway sets it
+// from the token.
+const userIDHeader = "X-User-ID"
+
+type ReviewHandler struct {
+	service services.ReviewService
+	logger  *slog.Logger
+}
+
+func NewReviewHandler(service services.ReviewService, logger *slog.Logger) *ReviewHandler {
+	return &ReviewHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *ReviewHandler) RegisterRoutes(ctx *gin.Engine) {
+	movies := ctx.Group("/movies")
+	{
+		movies.GET("/:id/reviews", h.List)
+		movies.POST("/:id/reviews", h.Create)
+	}
+
+	api := ctx.Group("/reviews")
+	{
+		api.GET("/", h.ModerationQueue)
+		api.PATCH("/:id", h.Update)
+		api.DELETE("/:id", h.Delete)
+		api.PATCH("/:id/moderation", h.Moderate)
+	}
+}
+
+func (h *ReviewHandler) List(ctx *gin.Context) {
+
+	movieID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+	if err != nil {
+		h.logger.Error("invalid movie id param", slog.String("param", ctx.Param("id")), slog.Any("error", err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid movie id"})
+		return
+	}
+
+	var query dto.ReviewListQuery
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.service.ListForMovie(uint(movieID), query)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "movie not found"})
+			return
+		}
+		if errors.Is(err, services.ErrInvalidReviewQuery) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.Error("failed to list reviews", slog.Any("movie_id", movieID), slog.Any("error", err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list reviews"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+func (h *ReviewHandler) Create(ctx *gin.Context) {
+
+	userID, ok := requestUserID(ctx)
+	if !ok {
+		return
+	}
+
+	movieID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+	if err != nil {
+		h.logger.Error("invalid movie id param", slog.String("param", ctx.Param("id")), slog.Any("error", err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid movie id"})
+		return
+	}
+
+	var req dto.ReviewCreateRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("invalid create review request", slog.Any("error", err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	review, err := h.service.Create(uint(movieID), userID, &req)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "movie not found"})
+			return
+		}
+		if status, ok := reviewErrorStatus(err); ok {
+			ctx.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.Error("review create failed", slog.Any("movie_id", movieID), slog.Any("error", err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "review create error"})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, review)
+}
+
+func (h *ReviewHandler) Update(ctx *gin.Context) {
+
+	userID, ok := requestUserID(ctx)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+	if err != nil {
+		h.logger.Error("invalid review id param", slog.String("param", ctx.Param("id")), slog.Any("error", err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return
+	}
+
+	var req dto.ReviewUpdateRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("invalid update review request", slog.Any("error", err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	review, err := h.service.Update(uint(id), userID, &req)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
+			return
+		}
+		if status, ok := reviewErrorStatus(err); ok {
+			ctx.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.Error("review update failed", slog.Any("id", id), slog.Any("error", err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "review update error"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, review)
+}
+
+func (h *ReviewHandler) Delete(ctx *gin.Context) {
+
+	userID, ok := requestUserID(ctx)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+	if err != nil {
+		h.logger.Error("invalid review id param", slog.String("param", ctx.Param("id")), slog.Any("error", err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return
+	}
+
+	if err := h.service.Delete(uint(id), userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
+			return
+		}
+		if status, ok := reviewErrorStatus(err); ok {
+			ctx.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.Error("failed to delete review", slog.Any("id", id), slog.Any("error", err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete review"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "review deleted successfully"})
+}
+
+func (h *ReviewHandler) ModerationQueue(ctx *gin.Context) {
+
+	var query dto.ReviewModerationQuery
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.service.ListForModeration(query)
+
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidReviewQuery) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.Error("failed to list reviews for moderation", slog.Any("error", err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list reviews"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+func (h *ReviewHandler) Moderate(ctx *gin.Context) {
+
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+
+	if err != nil {
+		h.logger.Error("invalid review id param", slog.String("param", ctx.Param("id")), slog.Any("error", err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid review id"})
+		return
+	}
+
+	var req dto.ReviewModerationRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("invalid review moderation request", slog.Any("error", err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// the moderator is recorded when the gateway passes one
+	moderatorID, _ := strconv.ParseUint(ctx.GetHeader(userIDHeader), 10, 64)
+
+	review, err := h.service.Moderate(uint(id), uint(moderatorID), &req)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "review not found"})
+			return
+		}
+		h.logger.Error("review moderation failed", slog.Any("id", id), slog.Any("error", err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "review moderation error"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, review)
+}
+
+// requestUserID reads the authenticated user, answering 401 when there is
+// none.
+func requestUserID(ctx *gin.Context) (uint, bool) {
+	userID, err := strconv.ParseUint(ctx.GetHeader(userIDHeader), 10, 64)
+	if err != nil || userID == 0 {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return 0, false
+	}
+	return uint(userID), true
+}
+
+func reviewErrorStatus(err error) (int, bool) {
+	switch {
+	case errors.Is(err, services.ErrReviewNotAllowed),
+		errors.Is(err, services.ErrNotReviewAuthor):
+		return http.StatusForbidden, true
+	case errors.Is(err, repository.ErrDuplicateReview):
+		return http.StatusConflict, true
+	}
+	return 0, false
+}
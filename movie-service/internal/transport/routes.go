@@ -11,11 +11,32 @@ func RegisterRoutes(
 	routes *gin.Engine,
 	movieService services.MovieService,
 	genreService services.GenreService,
+	personService services.PersonService,
+	creditService services.CreditService,
+	mediaService services.MediaService,
+	importService services.ImportService,
+	reviewService services.ReviewService,
+	recommendationService services.RecommendationService,
+	maxUploadSize int64,
+	maxImportSize int64,
+	catalogLanguage string,
 	logger *slog.Logger,
 ) {
-	movieHandler := NewMovieHandler(movieService, logger)
-	genreHandler := NewGenreHandler(genreService, logger)
+	movieHandler := NewMovieHandler(movieService, catalogLanguage, logger)
+	genreHandler := NewGenreHandler(genreService, catalogLanguage, logger)
+	personHandler := NewPersonHandler(personService, catalogLanguage, logger)
+	creditHandler := NewCreditHandler(creditService, logger)
+	mediaHandler := NewMediaHandler(mediaService, maxUploadSize, logger)
+	importHandler := NewImportHandler(importService, maxImportSize, logger)
+	reviewHandler := NewReviewHandler(reviewService, logger)
+	recommendationHandler := NewRecommendationHandler(recommendationService, catalogLanguage, logger)
 
 	movieHandler.RegisterRoutes(routes)
 	genreHandler.RegisterRoutes(routes)
+	personHandler.RegisterRoutes(routes)
+	creditHandler.RegisterRoutes(routes)
+	mediaHandler.RegisterRoutes(routes)
+	importHandler.RegisterRoutes(routes)
+	reviewHandler.RegisterRoutes(routes)
+	recommendationHandler.RegisterRoutes(routes)
 }
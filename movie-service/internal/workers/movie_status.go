@@ -0,0 +1,24 @@
+package workers
+
+import (
+	"log/slog"
+	"movie-service/internal/services"
+	"time"
+)
+
+func StartMovieStatusWorker(statusService services.MovieStatusService, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	logger.Info("movie status worker started", slog.String("interval", interval.String()))
+
+	if err := statusService.Refresh(); err != nil {
+		logger.Error("failed to refresh movie statuses on startup", slog.Any("error", err))
+	}
+
+	for range ticker.C {
+		if err := statusService.Refresh(); err != nil {
+			logger.Error("failed to refresh movie statuses", slog.Any("error", err))
+		}
+	}
+}
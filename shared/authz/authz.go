@@ -0,0 +1,111 @@
+// Package authz checks the tokens user-service issues. Every service that
+// guards routes uses it, so a token means the same thing everywhere.
+package authz
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	PermBookingsOwn     = "bookings:own"
+	PermBookingsRead    = "bookings:read"
+	PermBookingsWrite   = "bookings:write"
+	PermMoviesWrite     = "movies:write"
+	PermSessionsWrite   = "sessions:write"
+	PermHallsWrite      = "halls:write"
+	PermReviewsModerate = "reviews:moderate"
+	PermUsersRead       = "users:read"
+	PermUsersWrite      = "users:write"
+	PermRolesWrite      = "roles:write"
+)
+
+// Claims are the token claims user-service signs. TokenVersion is the user's
+// token version at sign-in; changing the password bumps it.
+type Claims struct {
+	UserID       uint     `json:"user_id"`
+	Role         string   `json:"role"`
+	Permissions  []string `json:"permissions"`
+	TokenVersion uint     `json:"token_version"`
+	jwt.RegisteredClaims
+}
+
+// ErrTokenRevoked is returned by a TokenCheck for a token that is no longer
+// current, such as one issued before a password change.
+var ErrTokenRevoked = errors.New("token revoked")
+
+// TokenCheck tells whether a validly signed token is still current. Errors
+// other than ErrTokenRevoked mean the check itself failed.
+type TokenCheck func(c *gin.Context, claims *Claims) error
+
+// Authenticate validates the bearer token, asks check whether it is still
+// current and stores its user_id, role and permissions on the context.
+func Authenticate(secret []byte, check TokenCheck) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing authorization header"})
+			return
+		}
+
+		scheme, tokenString, ok := strings.Cut(header, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid authorization header"})
+			return
+		}
+
+		claims := &Claims{}
+		token, err := jwt.ParseWithClaims(tokenString, claims,
+			func(token *jwt.Token) (interface{}, error) { return secret, nil },
+			jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		)
+		if err != nil || !token.Valid {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			return
+		}
+
+		if err := check(c, claims); err != nil {
+			if errors.Is(err, ErrTokenRevoked) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "failed to verify token"})
+			return
+		}
+
+		c.Set("user_id", claims.UserID)
+		c.Set("role", claims.Role)
+		c.Set("permissions", claims.Permissions)
+
+		c.Next()
+	}
+}
+
+// RequirePermission allows the request only if the caller's token carries
+// the given permission. It must run after Authenticate.
+func RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !HasPermission(c, permission) {
+			Forbid(c, permission)
+			return
+		}
+		c.Next()
+	}
+}
+
+func HasPermission(c *gin.Context, permission string) bool {
+	perms, _ := c.Get("permissions")
+	list, ok := perms.([]string)
+	return ok && slices.Contains(list, permission)
+}
+
+// Forbid aborts with the response RequirePermission gives, for handlers
+// that decide on a permission from the request itself.
+func Forbid(c *gin.Context, permission string) {
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "permission required: " + permission})
+}
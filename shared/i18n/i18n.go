@@ -0,0 +1,115 @@
+// Package i18n picks the response language from Accept-Language and
+// translates error messages. Every service describes its messages in a
+// Catalogue: each message has a stable ID, the English text responses carry
+// and translations keyed by that ID, so rewording a message keeps them.
+package i18n
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+// DefaultLanguage is the language messages are written in.
+const DefaultLanguage = "en"
+
+const languagesKey = "i18n.languages"
+
+// Catalogue holds the messages of one service and their translations.
+type Catalogue struct {
+	ids          map[string]string
+	translations map[string]map[string]string
+}
+
+// NewCatalogue builds a catalogue from messages, which maps message IDs to
+// their English text, and translations, which maps a language to texts by
+// message ID. It panics on a translation of an unknown ID or on two IDs
+// with the same text, so a broken catalogue fails at startup.
+func NewCatalogue(messages map[string]string, translations map[string]map[string]string) *Catalogue {
+	ids := make(map[string]string, len(messages))
+	for id, text := range messages {
+		if other, ok := ids[text]; ok {
+			panic(fmt.Sprintf("i18n: messages %q and %q have the same text", other, id))
+		}
+		ids[text] = id
+	}
+
+	for lang, texts := range translations {
+		for id := range texts {
+			if _, ok := messages[id]; !ok {
+				panic(fmt.Sprintf("i18n: %s translation of unknown message %q", lang, id))
+			}
+		}
+	}
+
+	return &Catalogue{ids: ids, translations: translations}
+}
+
+// Languages returns the base language codes the client accepts, most
+// preferred first: "ru-RU, en;q=0.8" gives [ru en].
+func Languages(ctx *gin.Context) []string {
+	if langs, ok := ctx.Get(languagesKey); ok {
+		return langs.([]string)
+	}
+
+	langs := parseAcceptLanguage(ctx.GetHeader("Accept-Language"))
+	ctx.Set(languagesKey, langs)
+	return langs
+}
+
+func parseAcceptLanguage(header string) []string {
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+
+	langs := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		base, confidence := tag.Base()
+		if confidence == language.No {
+			continue
+		}
+		// "*" parses as "mul"
+		code := base.String()
+		if code == "und" || code == "mul" || slices.Contains(langs, code) {
+			continue
+		}
+		langs = append(langs, code)
+	}
+	return langs
+}
+
+// MessageLanguage is the first accepted language messages can be shown in.
+func (c *Catalogue) MessageLanguage(langs []string) string {
+	for _, lang := range langs {
+		if lang == DefaultLanguage {
+			return lang
+		}
+		if _, ok := c.translations[lang]; ok {
+			return lang
+		}
+	}
+	return DefaultLanguage
+}
+
+// Translate returns the message in lang. For "known prefix: detail" only the
+// prefix is translated; unknown messages are returned unchanged.
+func (c *Catalogue) Translate(lang, message string) string {
+	texts := c.translations[lang]
+	if texts == nil {
+		return message
+	}
+
+	if translated, ok := texts[c.ids[message]]; ok {
+		return translated
+	}
+	if prefix, detail, ok := strings.Cut(message, ": "); ok {
+		if translated, ok := texts[c.ids[prefix]]; ok {
+			return translated + ": " + detail
+		}
+	}
+	return message
+}
@@ -0,0 +1,130 @@
+package i18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware translates the "error" field of JSON error responses into the
+// language the client asked for.
+func (c *Catalogue) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Writer.Header().Add("Vary", "Accept-Language")
+
+		lang := c.MessageLanguage(Languages(ctx))
+		if lang == DefaultLanguage {
+			ctx.Next()
+			return
+		}
+
+		writer := &errorWriter{ResponseWriter: ctx.Writer, catalogue: c}
+		ctx.Writer = writer
+		defer func() {
+			ctx.Writer = writer.ResponseWriter
+			writer.flush(lang)
+		}()
+
+		ctx.Next()
+	}
+}
+
+// errorWriter holds back responses with an error status so the message can
+// be translated before anything reaches the client. Other responses pass
+// straight through.
+type errorWriter struct {
+	gin.ResponseWriter
+	catalogue *Catalogue
+	status    int
+	body      bytes.Buffer
+}
+
+func (w *errorWriter) WriteHeader(code int) {
+	if code >= http.StatusBadRequest {
+		w.status = code
+		return
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *errorWriter) WriteHeaderNow() {
+	if w.status == 0 {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *errorWriter) Write(data []byte) (int, error) {
+	if w.status != 0 {
+		return w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *errorWriter) WriteString(s string) (int, error) {
+	if w.status != 0 {
+		return w.body.WriteString(s)
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *errorWriter) Status() int {
+	if w.status != 0 {
+		return w.status
+	}
+	return w.ResponseWriter.Status()
+}
+
+func (w *errorWriter) Written() bool {
+	return w.status != 0 || w.ResponseWriter.Written()
+}
+
+func (w *errorWriter) flush(lang string) {
+	if w.status == 0 {
+		return
+	}
+
+	body := w.body.Bytes()
+	if translated, ok := translateError(w.catalogue, body, lang); ok {
+		body = translated
+		w.Header().Del("Content-Length")
+		w.Header().Set("Content-Language", lang)
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	if len(body) == 0 {
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+	_, _ = w.ResponseWriter.Write(body)
+}
+
+// translateError rewrites {"error": "..."} bodies; anything else is left as
+// it is.
+func translateError(c *Catalogue, body []byte, lang string) ([]byte, bool) {
+	var payload map[string]any
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&payload); err != nil {
+		return nil, false
+	}
+
+	message, ok := payload["error"].(string)
+	if !ok {
+		return nil, false
+	}
+
+	translated := c.Translate(lang, message)
+	if translated == message {
+		return nil, false
+	}
+	payload["error"] = translated
+
+	out, err := json.Marshal(payload)
+	if err != nil {
+		return nil, false
+	}
+	return out, true
+}
@@ -0,0 +1,34 @@
+// Package retry repeats work that must not be skipped, such as handling a
+// Kafka event before its offset is committed.
+package retry
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	initialDelay = time.Second
+	maxDelay     = time.Minute
+)
+
+// Until runs fn until it succeeds, doubling the delay between attempts up to
+// a minute. failed is told about every error before the next attempt. Until
+// returns false if ctx is done first.
+func Until(ctx context.Context, fn func() error, failed func(err error, attempt int, next time.Duration)) bool {
+	delay := initialDelay
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return true
+		}
+		failed(err, attempt, delay)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxDelay)
+	}
+}
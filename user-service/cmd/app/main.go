@@ -1,15 +1,19 @@
 package main
 
 import (
+	"context"
 	"log"
 	"log/slog"
 	"os"
+	"user-service/internal/auth"
 	"user-service/internal/config"
 	"user-service/internal/kafka"
+	"user-service/internal/messages"
 	"user-service/internal/models"
 	"user-service/internal/repository"
 	"user-service/internal/services"
 	"user-service/internal/transport"
+	"user-service/internal/workers"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -21,12 +25,23 @@ func main() {
 
 	db := config.SetupDatabase()
 
-	if err := db.AutoMigrate(&models.User{}); err != nil {
+	if err := db.AutoMigrate(&models.User{}, &models.RolePermission{}, &models.OutboxEvent{}); err != nil {
+		log.Fatal(err)
+	}
+
+	// users created before roles were introduced carry the legacy "user" role
+	if err := db.Model(&models.User{}).Where("role = ?", "user").Update("role", auth.RoleCustomer).Error; err != nil {
 		log.Fatal(err)
 	}
 
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 	userRepo := repository.NewUserRepository(db, logger)
+	roleRepo := repository.NewRoleRepository(db, logger)
+	outboxRepo := repository.NewOutboxRepository(db, logger)
+
+	if err := roleRepo.Seed(auth.DefaultPermissions); err != nil {
+		log.Fatal(err)
+	}
 
 	broker := os.Getenv("KAFKA_BROKER")
 	if broker == "" {
@@ -34,16 +49,40 @@ func main() {
 	}
 
 	producer := kafka.NewProducer(broker)
+	go workers.StartOutboxRelay(context.Background(), outboxRepo, producer, logger)
+
+	accountGuard := auth.NewLoginGuard(
+		config.LoginMaxAttempts(),
+		config.LoginLockoutBase(),
+		config.LoginLockoutMax(),
+		config.LoginAttemptWindow(),
+		config.LoginGuardMaxEntries(),
+	)
+	ipGuard := auth.NewLoginGuard(
+		config.LoginMaxAttemptsPerIP(),
+		config.LoginLockoutBase(),
+		config.LoginLockoutMax(),
+		config.LoginAttemptWindow(),
+		config.LoginGuardMaxEntries(),
+	)
+
+	authService := services.NewAuthService(userRepo, roleRepo, outboxRepo, producer, accountGuard, ipGuard, logger)
 
-	authService := services.NewAuthService(userRepo, producer, logger)
+	userService := services.NewUserService(userRepo, outboxRepo, producer, logger)
+	roleService := services.NewRoleService(roleRepo, logger)
 
-	userService := services.NewUserService(userRepo, logger)
+	kafka.StartErasureCompletedConsumer(context.Background(), broker, userService.CompleteErasure, logger)
 
 	authHandler := transport.NewAuthHandler(authService)
 	userHandler := transport.NewUserHandler(userService, logger)
+	roleHandler := transport.NewRoleHandler(roleService, logger)
 
 	r := gin.Default()
-	transport.RegisterRouters(r, authHandler, userHandler)
+	if err := r.SetTrustedProxies(config.TrustedProxies()); err != nil {
+		log.Fatal(err)
+	}
+	r.Use(messages.Catalogue.Middleware())
+	transport.RegisterRouters(r, authHandler, userHandler, roleHandler, userRepo)
 
 	if err := r.Run(":8080"); err != nil {
 		log.Fatal(err)
@@ -6,8 +6,12 @@ require (
 	github.com/gin-gonic/gin v1.11.0
 	github.com/gohugoio/hugo v0.154.5
 	github.com/golang-jwt/jwt/v5 v5.2.3
+	github.com/joho/godotenv v1.5.1
+	github.com/segmentio/kafka-go v0.4.50
 	golang.org/x/crypto v0.47.0
+	gorm.io/driver/postgres v1.6.0
 	gorm.io/gorm v1.31.1
+	shared v0.0.0
 )
 
 require (
@@ -39,7 +43,6 @@ require (
 	github.com/jackc/puddle/v2 v2.2.2 // indirect
 	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/jinzhu/now v1.1.5 // indirect
-	github.com/joho/godotenv v1.5.1 // indirect
 	github.com/json-iterator/go v1.1.12 // indirect
 	github.com/klauspost/compress v1.15.9 // indirect
 	github.com/klauspost/cpuid/v2 v2.3.0 // indirect
@@ -56,7 +59,6 @@ require (
 	github.com/pkg/errors v0.9.1 // indirect
 	github.com/quic-go/qpack v0.6.0 // indirect
 	github.com/quic-go/quic-go v0.59.0 // indirect
-	github.com/segmentio/kafka-go v0.4.50 // indirect
 	github.com/spf13/afero v1.15.0 // indirect
 	github.com/spf13/cast v1.10.0 // indirect
 	github.com/tdewolff/parse/v2 v2.8.5 // indirect
@@ -70,5 +72,6 @@ require (
 	golang.org/x/text v0.33.0 // indirect
 	golang.org/x/tools v0.41.0 // indirect
 	google.golang.org/protobuf v1.36.11 // indirect
-	gorm.io/driver/postgres v1.6.0 // indirect
 )
+
+replace shared => ../shared
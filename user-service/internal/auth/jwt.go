@@ -2,6 +2,7 @@ package auth
 
 import (
 	"os"
+	"shared/authz"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -9,16 +10,12 @@ import (
 
 var jwtSecret = []byte(os.Getenv("JWT_SECRET"))
 
-type Claims struct {
-	UserID uint   `json:"user_id"`
-	Role   string `json:"role"`
-	jwt.RegisteredClaims
-}
-
-func GenerateToken(userID uint, role string) (string, error) {
-	claims := Claims{
-		UserID: userID,
-		Role:   role,
+func GenerateToken(userID uint, role string, permissions []string, tokenVersion uint) (string, error) {
+	claims := authz.Claims{
+		UserID:       userID,
+		Role:         role,
+		Permissions:  permissions,
+		TokenVersion: tokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
 		},
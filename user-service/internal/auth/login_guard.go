@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+// LoginGuard tracks failed login attempts per key (an email or a client IP)
+// and locks the key out with exponential backoff once the limit is reached.
+// Unknown emails are tracked the same way as existing ones so lockouts do not
+// reveal whether an account exists. Stale entries are swept once per window
+// and at most maxEntries keys are kept.
+type LoginGuard struct {
+	mu          sync.Mutex
+	entries     map[string]*attemptEntry
+	maxAttempts int
+	baseLockout time.Duration
+	maxLockout  time.Duration
+	window      time.Duration
+	maxEntries  int
+	lastSweep   time.Time
+}
+
+type attemptEntry struct {
+	failures    int
+	lastFailure time.Time
+	lockedUntil time.Time
+}
+
+func NewLoginGuard(maxAttempts int, baseLockout, maxLockout, window time.Duration, maxEntries int) *LoginGuard {
+	return &LoginGuard{
+		entries:     make(map[string]*attemptEntry),
+		maxAttempts: maxAttempts,
+		baseLockout: baseLockout,
+		maxLockout:  maxLockout,
+		window:      window,
+		maxEntries:  maxEntries,
+		lastSweep:   time.Now(),
+	}
+}
+
+// Locked returns how long the key stays locked, or zero if it is not locked.
+func (g *LoginGuard) Locked(key string) time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	e, ok := g.entries[key]
+	if !ok {
+		return 0
+	}
+
+	if now.Before(e.lockedUntil) {
+		return e.lockedUntil.Sub(now)
+	}
+
+	if now.Sub(e.lastFailure) > g.window {
+		delete(g.entries, key)
+	}
+
+	return 0
+}
+
+// Fail records a failed attempt and returns the number of consecutive
+// failures together with the lockout it triggered, if any.
+func (g *LoginGuard) Fail(key string) (failures int, lockout time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	e, ok := g.entries[key]
+	if !ok || now.Sub(e.lastFailure) > g.window {
+		if !ok {
+			g.makeRoom(now)
+		}
+		e = &attemptEntry{}
+		g.entries[key] = e
+	}
+
+	e.failures++
+	e.lastFailure = now
+
+	if e.failures < g.maxAttempts {
+		return e.failures, 0
+	}
+
+	lockout = g.baseLockout << (e.failures - g.maxAttempts)
+	if lockout <= 0 || lockout > g.maxLockout {
+		lockout = g.maxLockout
+	}
+	e.lockedUntil = now.Add(lockout)
+
+	return e.failures, lockout
+}
+
+func (g *LoginGuard) Reset(key string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.entries, key)
+}
+
+// makeRoom drops expired entries once per window, or sooner when the guard is
+// full. If it is still full, the entry with the oldest failure goes.
+func (g *LoginGuard) makeRoom(now time.Time) {
+	full := len(g.entries) >= g.maxEntries
+	if !full && now.Sub(g.lastSweep) < g.window {
+		return
+	}
+
+	for key, e := range g.entries {
+		if g.expired(e, now) {
+			delete(g.entries, key)
+		}
+	}
+	g.lastSweep = now
+
+	if len(g.entries) < g.maxEntries {
+		return
+	}
+
+	var oldestKey string
+	var oldest time.Time
+	for key, e := range g.entries {
+		if oldestKey == "" || e.lastFailure.Before(oldest) {
+			oldestKey, oldest = key, e.lastFailure
+		}
+	}
+	delete(g.entries, oldestKey)
+}
+
+func (g *LoginGuard) expired(e *attemptEntry, now time.Time) bool {
+	return !now.Before(e.lockedUntil) && now.Sub(e.lastFailure) > g.window
+}
@@ -0,0 +1,41 @@
+package auth
+
+import "shared/authz"
+
+const (
+	RoleCustomer = "customer"
+	RoleCashier  = "cashier"
+	RoleManager  = "manager"
+	RoleAdmin    = "admin"
+)
+
+var Roles = []string{RoleCustomer, RoleCashier, RoleManager, RoleAdmin}
+
+// DefaultPermissions is seeded into the database for roles that have no
+// permissions yet; after that the stored set is authoritative.
+var DefaultPermissions = map[string][]string{
+	RoleCustomer: {
+		authz.PermBookingsOwn,
+	},
+	RoleCashier: {
+		authz.PermBookingsOwn, authz.PermBookingsRead, authz.PermBookingsWrite, authz.PermUsersRead,
+	},
+	RoleManager: {
+		authz.PermBookingsOwn, authz.PermBookingsRead, authz.PermBookingsWrite, authz.PermUsersRead,
+		authz.PermMoviesWrite, authz.PermSessionsWrite, authz.PermHallsWrite, authz.PermReviewsModerate,
+	},
+	RoleAdmin: {
+		authz.PermBookingsOwn, authz.PermBookingsRead, authz.PermBookingsWrite, authz.PermUsersRead,
+		authz.PermMoviesWrite, authz.PermSessionsWrite, authz.PermHallsWrite, authz.PermReviewsModerate,
+		authz.PermUsersWrite, authz.PermRolesWrite,
+	},
+}
+
+func IsValidRole(role string) bool {
+	for _, r := range Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package clients
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+	"user-service/internal/config"
+)
+
+var httpClient = &http.Client{
+	Timeout: 5 * time.Second,
+}
+
+func GetUserBookings(userID uint) (json.RawMessage, error) {
+	url := fmt.Sprintf("%s/bookings/user/%d", config.BookingServiceURL(), userID)
+
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("booking service returned status %d for user %d", resp.StatusCode, userID)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if !json.Valid(body) {
+		return nil, fmt.Errorf("booking service returned invalid json for user %d", userID)
+	}
+
+	return body, nil
+}
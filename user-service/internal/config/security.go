@@ -0,0 +1,62 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func LoginMaxAttempts() int {
+	return envInt("LOGIN_MAX_ATTEMPTS", 5)
+}
+
+func LoginMaxAttemptsPerIP() int {
+	return envInt("LOGIN_MAX_ATTEMPTS_PER_IP", 20)
+}
+
+func LoginLockoutBase() time.Duration {
+	return envDuration("LOGIN_LOCKOUT_BASE", time.Minute)
+}
+
+func LoginLockoutMax() time.Duration {
+	return envDuration("LOGIN_LOCKOUT_MAX", time.Hour)
+}
+
+func LoginAttemptWindow() time.Duration {
+	return envDuration("LOGIN_ATTEMPT_WINDOW", 15*time.Minute)
+}
+
+// LoginGuardMaxEntries caps the emails and IPs each login guard remembers.
+func LoginGuardMaxEntries() int {
+	return envInt("LOGIN_GUARD_MAX_ENTRIES", 100000)
+}
+
+// TrustedProxies lists the addresses (IPs or CIDRs) allowed to report the
+// client IP in X-Forwarded-For; normally only the gateway. Empty means the
+// connecting address is the client.
+func TrustedProxies() []string {
+	var proxies []string
+	for _, p := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			proxies = append(proxies, p)
+		}
+	}
+	return proxies
+}
+
+func envInt(key string, def int) int {
+	v, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || v <= 0 {
+		return def
+	}
+	return v
+}
+
+func envDuration(key string, def time.Duration) time.Duration {
+	v, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || v <= 0 {
+		return def
+	}
+	return v
+}
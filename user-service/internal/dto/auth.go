@@ -1,9 +1,10 @@
 package dto
 
 type RegisterRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=6"`
-	Name     string `json:"name" binding:"required"`
+	Email     string `json:"email" binding:"required,email"`
+	Password  string `json:"password" binding:"required,min=6"`
+	Name      string `json:"name" binding:"required"`
+	BirthDate string `json:"birth_date" binding:"omitempty,datetime=2006-01-02"`
 }
 
 type LoginRequest struct {
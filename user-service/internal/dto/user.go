@@ -1,21 +1,77 @@
 package dto
 
+import (
+	"encoding/json"
+	"time"
+)
+
 type CreateUserRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=6"`
-	Name     string `json:"name" binding:"required"`
-	Role     string `json:"role"`
+	Email     string `json:"email" binding:"required,email"`
+	Password  string `json:"password" binding:"required,min=6"`
+	Name      string `json:"name" binding:"required"`
+	Role      string `json:"role" binding:"omitempty,oneof=customer cashier manager admin"`
+	BirthDate string `json:"birth_date" binding:"omitempty,datetime=2006-01-02"`
 }
 
+// UpdateUserRequest clears the birth date when it is sent as an empty string.
+// UpdateProfileRequest can only set a birth date that is still missing.
 type UpdateUserRequest struct {
-	Email *string `json:"email"`
-	Name  *string `json:"name"`
-	Role  *string `json:"role"`
+	Email     *string `json:"email"`
+	Name      *string `json:"name"`
+	Role      *string `json:"role" binding:"omitempty,oneof=customer cashier manager admin"`
+	BirthDate *string `json:"birth_date" binding:"omitempty,datetime=2006-01-02"`
+}
+
+type UpdateProfileRequest struct {
+	Email     *string `json:"email" binding:"omitempty,email"`
+	Name      *string `json:"name" binding:"omitempty,min=1"`
+	BirthDate *string `json:"birth_date" binding:"omitempty,datetime=2006-01-02"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6"`
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type SetRolePermissionsRequest struct {
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+type RoleResponse struct {
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+type UserExport struct {
+	ExportedAt time.Time       `json:"exported_at"`
+	Profile    ProfileExport   `json:"profile"`
+	Bookings   json.RawMessage `json:"bookings"`
+}
+
+type ProfileExport struct {
+	ID           uint      `json:"id"`
+	Email        string    `json:"email"`
+	Name         string    `json:"name"`
+	Role         string    `json:"role"`
+	BirthDate    string    `json:"birth_date,omitempty"`
+	PendingEmail string    `json:"pending_email,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
 }
 
 type UserResponse struct {
-	ID    uint   `json:"id"`
-	Email string `json:"email"`
-	Name  string `json:"name"`
-	Role  string `json:"role"`
+	ID           uint   `json:"id"`
+	Email        string `json:"email"`
+	Name         string `json:"name"`
+	Role         string `json:"role"`
+	BirthDate    string `json:"birth_date,omitempty"`
+	PendingEmail string `json:"pending_email,omitempty"`
 }
@@ -1,7 +1,29 @@
 package errors
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 var (
-	ErrUserAlreadyExists = errors.New("user already exists")
+	ErrUserAlreadyExists    = errors.New("user already exists")
+	ErrInvalidCredentials   = errors.New("invalid email or password")
+	ErrTooManyLoginAttempts = errors.New("too many login attempts")
+	ErrInvalidPassword      = errors.New("current password is incorrect")
+	ErrInvalidToken         = errors.New("invalid or expired verification token")
+	ErrUnknownRole          = errors.New("unknown role")
+	ErrInvalidBirthDate     = errors.New("birth date must be in the past")
+	ErrBirthDateLocked      = errors.New("birth date can only be changed by staff")
 )
+
+type LockoutError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockoutError) Error() string {
+	return ErrTooManyLoginAttempts.Error()
+}
+
+func (e *LockoutError) Is(target error) bool {
+	return target == ErrTooManyLoginAttempts
+}
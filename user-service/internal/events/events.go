@@ -0,0 +1,92 @@
+package events
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+	"user-service/internal/models"
+)
+
+const (
+	UserCreated     = "user.created"
+	UserUpdated     = "user.updated"
+	UserRoleChanged = "user.role_changed"
+	UserDeleted     = "user.deleted"
+	UserVerified    = "user.verified"
+	UserErased      = "user.erased"
+)
+
+const envelopeVersion = 1
+
+// Envelope wraps every user lifecycle event published by user-service.
+// The event type doubles as the Kafka topic and the user id as the key.
+type Envelope struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	Version    int       `json:"version"`
+	OccurredAt time.Time `json:"occurred_at"`
+	UserID     uint      `json:"user_id"`
+	Data       any       `json:"data"`
+}
+
+// BirthDate in the created and updated events uses the "2006-01-02" layout
+// and is empty when unknown.
+type UserCreatedData struct {
+	Email     string `json:"email"`
+	Name      string `json:"name"`
+	Role      string `json:"role"`
+	BirthDate string `json:"birth_date,omitempty"`
+}
+
+type UserUpdatedData struct {
+	Email     string   `json:"email"`
+	Name      string   `json:"name"`
+	BirthDate string   `json:"birth_date,omitempty"`
+	Fields    []string `json:"fields"`
+}
+
+type UserRoleChangedData struct {
+	OldRole string `json:"old_role"`
+	NewRole string `json:"new_role"`
+}
+
+type UserDeletedData struct {
+	Email string `json:"email"`
+}
+
+type UserVerifiedData struct {
+	Email string `json:"email"`
+}
+
+// UserErasedData carries the pseudonym other services should put in place
+// of the user id. It is not stored in user-service.
+type UserErasedData struct {
+	Pseudonym string `json:"pseudonym"`
+}
+
+func New(eventType string, userID uint, data any) (models.OutboxEvent, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return models.OutboxEvent{}, err
+	}
+
+	payload, err := json.Marshal(Envelope{
+		ID:         hex.EncodeToString(id),
+		Type:       eventType,
+		Version:    envelopeVersion,
+		OccurredAt: time.Now().UTC(),
+		UserID:     userID,
+		Data:       data,
+	})
+	if err != nil {
+		return models.OutboxEvent{}, err
+	}
+
+	return models.OutboxEvent{
+		Topic:   eventType,
+		Key:     strconv.FormatUint(uint64(userID), 10),
+		Payload: payload,
+	}, nil
+}
@@ -2,35 +2,61 @@ package kafka
 
 import (
 	"context"
+	"encoding/json"
 	"log/slog"
+	"shared/retry"
+	"time"
 
 	kafka "github.com/segmentio/kafka-go"
 )
 
-func StartUserCreatedConsumer(ctx context.Context, broker string, log *slog.Logger) {
+const TopicErasureCompleted = "booking.user_erased"
+
+type ErasureCompletedEvent struct {
+	UserID    uint   `json:"user_id"`
+	Pseudonym string `json:"pseudonym"`
+	Bookings  int64  `json:"bookings"`
+}
+
+// StartErasureCompletedConsumer calls handle for every erasure booking-service
+// has finished, committing the offset only once handle succeeds. A failing
+// event is retried rather than skipped.
+func StartErasureCompletedConsumer(ctx context.Context, broker string, handle func(userID uint) error, log *slog.Logger) {
 	r := kafka.NewReader(kafka.ReaderConfig{
 		Brokers: []string{broker},
-		Topic:   "user.created",
-		GroupID: "user-service-debug",
+		Topic:   TopicErasureCompleted,
+		GroupID: "user-service",
 	})
 
 	go func() {
 		defer r.Close()
 
-		log.Info("kafka consumer started")
+		log.Info("kafka consumer started", "topic", TopicErasureCompleted)
 
 		for {
-			msg, err := r.ReadMessage(ctx)
+			msg, err := r.FetchMessage(ctx)
 			if err != nil {
 				if ctx.Err() != nil {
-					log.Info("kafka consumer stopped")
+					log.Info("kafka consumer stopped", "topic", TopicErasureCompleted)
 					return
 				}
-				log.Error("failed to read kafka message", "err:", err)
+				log.Error("failed to read kafka message", "err", err)
 				continue
 			}
 
-			log.Info("user created event received", "value", string(msg.Value))
+			var event ErasureCompletedEvent
+			if err := json.Unmarshal(msg.Value, &event); err != nil {
+				log.Error("failed to decode erasure completed event", "offset", msg.Offset, "err", err)
+			} else if !retry.Until(ctx, func() error { return handle(event.UserID) }, func(err error, attempt int, next time.Duration) {
+				log.Error("failed to handle erasure completed event", "user_id", event.UserID, "attempt", attempt, "retry_in", next, "err", err)
+			}) {
+				log.Info("kafka consumer stopped", "topic", TopicErasureCompleted)
+				return
+			}
+
+			if err := r.CommitMessages(ctx, msg); err != nil {
+				log.Error("failed to commit kafka message", "offset", msg.Offset, "err", err)
+			}
 		}
 	}()
 }
@@ -3,13 +3,29 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/segmentio/kafka-go"
 )
 
-type UserCreatedEvent struct {
-	ID    uint   `json:"id"`
-	Email string `json:"email"`
+const (
+	TopicLoginLockout      = "auth.lockout"
+	TopicEmailVerification = "user.email_verification_requested"
+)
+
+type LoginLockoutEvent struct {
+	Scope       string    `json:"scope"`
+	Email       string    `json:"email,omitempty"`
+	IP          string    `json:"ip"`
+	Failures    int       `json:"failures"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+type EmailVerificationEvent struct {
+	ID        uint      `json:"id"`
+	Email     string    `json:"email"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 type Producer struct {
@@ -19,13 +35,30 @@ type Producer struct {
 func NewProducer(broker string) *Producer {
 	return &Producer{
 		writer: &kafka.Writer{
-			Addr:  kafka.TCP(broker),
-			Topic: "user.created",
+			Addr:                   kafka.TCP(broker),
+			AllowAutoTopicCreation: true,
 		},
 	}
 }
 
-func (p *Producer) SendUserCreated(event UserCreatedEvent) error {
+func (p *Producer) SendLoginLockout(event LoginLockoutEvent) error {
+	return p.send(TopicLoginLockout, event)
+}
+
+func (p *Producer) SendEmailVerification(event EmailVerificationEvent) error {
+	return p.send(TopicEmailVerification, event)
+}
+
+// Publish writes an already encoded message, as stored in the outbox.
+func (p *Producer) Publish(ctx context.Context, topic, key string, value []byte) error {
+	return p.writer.WriteMessages(ctx, kafka.Message{
+		Topic: topic,
+		Key:   []byte(key),
+		Value: value,
+	})
+}
+
+func (p *Producer) send(topic string, event any) error {
 	data, err := json.Marshal(event)
 	if err != nil {
 		return err
@@ -34,6 +67,7 @@ func (p *Producer) SendUserCreated(event UserCreatedEvent) error {
 	return p.writer.WriteMessages(
 		context.Background(),
 		kafka.Message{
+			Topic: topic,
 			Value: data,
 		},
 	)
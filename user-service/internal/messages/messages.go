@@ -0,0 +1,43 @@
+// Package messages lists the error messages the service responds with and
+// their translations.
+package messages
+
+import "shared/i18n"
+
+// Catalogue translates the service's error responses.
+var Catalogue = i18n.NewCatalogue(english, map[string]map[string]string{
+	"ru": ru,
+})
+
+// english maps message IDs to the text responses carry. Translations refer
+// to the IDs, so a message can be reworded here alone.
+var english = map[string]string{
+	// handlers and middleware
+	"auth.access_denied":           "access denied",
+	"auth.check_failed":            "failed to verify token",
+	"auth.invalid_claims":          "invalid token claims",
+	"auth.invalid_header":          "invalid authorization header",
+	"auth.invalid_token":           "invalid token",
+	"auth.login_throttled_retry":   "too many login attempts, try again later",
+	"auth.missing_header":          "missing authorization header",
+	"auth.permission_required":     "permission required",
+	"auth.wrong_password":          "password is incorrect",
+	"internal":                     "internal error",
+	"not_found":                    "not found",
+	"request.invalid_body":         "invalid request body",
+	"request.invalid_user_id":      "invalid user id",
+	"upstream.booking_unavailable": "booking service unavailable",
+	"user.email_taken":             "email already exists",
+	"user.export_failed":           "failed to export user data",
+	"user.not_found":               "user not found",
+
+	// services
+	"auth.invalid_credentials":        "invalid email or password",
+	"auth.login_throttled":            "too many login attempts",
+	"user.birth_date_in_future":       "birth date must be in the past",
+	"user.birth_date_locked":          "birth date can only be changed by staff",
+	"user.exists":                     "user already exists",
+	"user.invalid_verification_token": "invalid or expired verification token",
+	"user.unknown_role":               "unknown role",
+	"user.wrong_current_password":     "current password is incorrect",
+}
@@ -0,0 +1,32 @@
+package messages
+
+var ru = map[string]string{
+	// handlers and middleware
+	"auth.access_denied":           "доступ запрещён",
+	"auth.check_failed":            "не удалось проверить токен",
+	"auth.invalid_claims":          "некорректные данные токена",
+	"auth.invalid_header":          "некорректный заголовок Authorization",
+	"auth.invalid_token":           "недействительный токен",
+	"auth.login_throttled_retry":   "слишком много попыток входа, попробуйте позже",
+	"auth.missing_header":          "отсутствует заголовок Authorization",
+	"auth.permission_required":     "требуется разрешение",
+	"auth.wrong_password":          "неверный пароль",
+	"internal":                     "внутренняя ошибка",
+	"not_found":                    "не найдено",
+	"request.invalid_body":         "некорректное тело запроса",
+	"request.invalid_user_id":      "некорректный id пользователя",
+	"upstream.booking_unavailable": "сервис бронирования недоступен",
+	"user.email_taken":             "email уже зарегистрирован",
+	"user.export_failed":           "не удалось выгрузить данные пользователя",
+	"user.not_found":               "пользователь не найден",
+
+	// services
+	"auth.invalid_credentials":        "неверный email или пароль",
+	"auth.login_throttled":            "слишком много попыток входа",
+	"user.birth_date_in_future":       "дата рождения должна быть в прошлом",
+	"user.birth_date_locked":          "дату рождения может изменить только сотрудник",
+	"user.exists":                     "пользователь уже существует",
+	"user.invalid_verification_token": "ссылка подтверждения недействительна или устарела",
+	"user.unknown_role":               "неизвестная роль",
+	"user.wrong_current_password":     "текущий пароль неверен",
+}
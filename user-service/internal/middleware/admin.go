@@ -1,21 +0,0 @@
-package middleware
-
-import (
-	"net/http"
-
-	"github.com/gin-gonic/gin"
-)
-
-func AdminMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		role, ok := c.Get("role")
-		if !ok || role != "admin" {
-			c.JSON(http.StatusForbidden, gin.H{
-				"error": "admin access required",
-			})
-			c.Abort()
-			return
-		}
-		c.Next()
-	}
-}
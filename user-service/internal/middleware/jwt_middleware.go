@@ -1,60 +1,29 @@
 package middleware
 
 import (
-	"net/http"
-	"strings"
+	"errors"
+	"shared/authz"
 	"user-service/internal/auth"
+	"user-service/internal/repository"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
 )
 
-func JWTMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "missing authorization header",
-			})
-			return
+// JWTMiddleware authenticates the caller and rejects tokens of deleted users
+// and tokens issued before the user's last password change.
+func JWTMiddleware(users repository.UserRepository) gin.HandlerFunc {
+	return authz.Authenticate(auth.JwtSecret(), func(c *gin.Context, claims *authz.Claims) error {
+		user, err := users.GetByID(claims.UserID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return authz.ErrTokenRevoked
+			}
+			return err
 		}
-
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "invalid authorization header",
-			})
-			return
-		}
-
-		tokenString := parts[1]
-
-		token, err := jwt.ParseWithClaims(
-			tokenString,
-			&auth.Claims{},
-			func(token *jwt.Token) (interface{}, error) {
-				return auth.JwtSecret(), nil
-			},
-		)
-
-		if err != nil || !token.Valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "invalid token",
-			})
-			return
+		if user.TokenVersion != claims.TokenVersion {
+			return authz.ErrTokenRevoked
 		}
-
-		claims, ok := token.Claims.(*auth.Claims)
-		if !ok {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "invalid token claims",
-			})
-			return
-		}
-		c.Set("user_id", claims.UserID)
-		c.Set("role", claims.Role)
-
-		c.Next()
-
-	}
+		return nil
+	})
 }
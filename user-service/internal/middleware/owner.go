@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+	"shared/authz"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OwnerOrPermission lets a request through only when the :id path parameter
+// is the caller's own user id or the caller holds the given permission.
+func OwnerOrPermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if authz.HasPermission(c, permission) {
+			c.Next()
+			return
+		}
+
+		id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil || uint(id) != c.GetUint("user_id") {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "access denied",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
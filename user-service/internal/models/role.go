@@ -0,0 +1,7 @@
+package models
+
+type RolePermission struct {
+	ID         uint   `gorm:"primaryKey" json:"-"`
+	Role       string `gorm:"type:varchar(50);not null;uniqueIndex:idx_role_permission" json:"role"`
+	Permission string `gorm:"type:varchar(100);not null;uniqueIndex:idx_role_permission" json:"permission"`
+}
@@ -1,11 +1,38 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const DateLayout = "2006-01-02"
 
 type User struct {
 	gorm.Model
 	Email    string `gorm:"unique;not null" json:"email"`
 	Password string `gorm:"not null" json:"-"`
 	Name     string `gorm:"not null" json:"name"`
-	Role     string `gorm:"not null;default:user" json:"role"`
+	Role     string `gorm:"not null;default:customer" json:"role"`
+	// TokenVersion is signed into tokens; bumping it revokes the tokens
+	// issued before.
+	TokenVersion uint `gorm:"not null;default:0" json:"-"`
+
+	BirthDate *time.Time `gorm:"type:date" json:"birth_date,omitempty"`
+
+	PendingEmail             string     `gorm:"type:varchar(255)" json:"pending_email,omitempty"`
+	EmailVerificationToken   string     `gorm:"type:varchar(64);index" json:"-"`
+	EmailVerificationExpires *time.Time `json:"-"`
+
+	ErasedAt           *time.Time `json:"-"`
+	ErasureCompletedAt *time.Time `json:"-"`
+}
+
+// BirthDateString formats the birth date as "2006-01-02", or returns "" when
+// it is unknown.
+func (u *User) BirthDateString() string {
+	if u.BirthDate == nil {
+		return ""
+	}
+	return u.BirthDate.Format(DateLayout)
 }
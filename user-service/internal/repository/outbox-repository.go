@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"log/slog"
+	"time"
+	"user-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type OutboxRepository interface {
+	Add(tx *gorm.DB, events ...models.OutboxEvent) error
+	ListPending(limit int) ([]models.OutboxEvent, error)
+	MarkPublished(id uint) error
+	MarkFailed(id uint, cause error) error
+}
+
+type outboxRepository struct {
+	db  *gorm.DB
+	log *slog.Logger
+}
+
+func NewOutboxRepository(db *gorm.DB, log *slog.Logger) OutboxRepository {
+	return &outboxRepository{db: db, log: log}
+}
+
+func (r *outboxRepository) Add(tx *gorm.DB, events ...models.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	if err := tx.Create(&events).Error; err != nil {
+		r.log.Error("failed to add outbox events", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (r *outboxRepository) ListPending(limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	if err := r.db.Where("published_at IS NULL").
+		Order("id").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		r.log.Error("failed to list pending outbox events", "err", err)
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *outboxRepository) MarkPublished(id uint) error {
+	if err := r.db.Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		Update("published_at", time.Now()).Error; err != nil {
+		r.log.Error("failed to mark outbox event published", "id", id, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (r *outboxRepository) MarkFailed(id uint, cause error) error {
+	if err := r.db.Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": cause.Error(),
+		}).Error; err != nil {
+		r.log.Error("failed to mark outbox event failed", "id", id, "err", err)
+		return err
+	}
+	return nil
+}
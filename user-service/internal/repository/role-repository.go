@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"log/slog"
+	"user-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type RoleRepository interface {
+	Permissions(role string) ([]string, error)
+	All() (map[string][]string, error)
+	SetPermissions(role string, permissions []string) error
+	Seed(defaults map[string][]string) error
+}
+
+type roleRepository struct {
+	db  *gorm.DB
+	log *slog.Logger
+}
+
+func NewRoleRepository(db *gorm.DB, log *slog.Logger) RoleRepository {
+	return &roleRepository{db: db, log: log}
+}
+
+func (r *roleRepository) Permissions(role string) ([]string, error) {
+	var perms []string
+	if err := r.db.Model(&models.RolePermission{}).
+		Where("role = ?", role).
+		Order("permission").
+		Pluck("permission", &perms).Error; err != nil {
+		r.log.Error("failed to get role permissions", "role", role, "err", err)
+		return nil, err
+	}
+	return perms, nil
+}
+
+func (r *roleRepository) All() (map[string][]string, error) {
+	var rows []models.RolePermission
+	if err := r.db.Order("role, permission").Find(&rows).Error; err != nil {
+		r.log.Error("failed to list role permissions", "err", err)
+		return nil, err
+	}
+
+	result := make(map[string][]string)
+	for _, row := range rows {
+		result[row.Role] = append(result[row.Role], row.Permission)
+	}
+	return result, nil
+}
+
+func (r *roleRepository) SetPermissions(role string, permissions []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role = ?", role).Delete(&models.RolePermission{}).Error; err != nil {
+			r.log.Error("failed to clear role permissions", "role", role, "err", err)
+			return err
+		}
+
+		if len(permissions) == 0 {
+			return nil
+		}
+
+		rows := make([]models.RolePermission, 0, len(permissions))
+		for _, p := range permissions {
+			rows = append(rows, models.RolePermission{Role: role, Permission: p})
+		}
+
+		if err := tx.Create(&rows).Error; err != nil {
+			r.log.Error("failed to set role permissions", "role", role, "err", err)
+			return err
+		}
+		return nil
+	})
+}
+
+func (r *roleRepository) Seed(defaults map[string][]string) error {
+	for role, perms := range defaults {
+		var count int64
+		if err := r.db.Model(&models.RolePermission{}).Where("role = ?", role).Count(&count).Error; err != nil {
+			r.log.Error("failed to count role permissions", "role", role, "err", err)
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+
+		if err := r.SetPermissions(role, perms); err != nil {
+			return err
+		}
+		r.log.Info("seeded role permissions", "role", role, "count", len(perms))
+	}
+	return nil
+}
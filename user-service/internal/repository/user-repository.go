@@ -2,6 +2,7 @@ package repository
 
 import (
 	"log/slog"
+	"time"
 	"user-service/internal/models"
 
 	"gorm.io/gorm"
@@ -11,9 +12,14 @@ type UserRepository interface {
 	Create(user *models.User) error
 	GetByID(id uint) (*models.User, error)
 	GetByEmail(email string) (*models.User, error)
+	GetByVerificationToken(token string) (*models.User, error)
 	GetAll() ([]models.User, error)
 	Update(user *models.User) error
 	Delete(id uint) error
+	MarkErasureCompleted(id uint) error
+
+	WithTx(tx *gorm.DB) UserRepository
+	Transaction(fn func(tx *gorm.DB) error) error
 }
 
 type userRepository struct {
@@ -42,15 +48,26 @@ func (r *userRepository) GetByID(id uint) (*models.User, error) {
 	return &u, nil
 }
 
+// GetByEmail expects a lowercased email. Rows stored before emails were
+// lowercased are matched too.
 func (r *userRepository) GetByEmail(email string) (*models.User, error) {
 	var user models.User
-	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
+	if err := r.db.Where("LOWER(email) = ?", email).First(&user).Error; err != nil {
 		r.log.Error("failed to get user by email", "email", email, "err", err)
 		return nil, err
 	}
 	return &user, nil
 }
 
+func (r *userRepository) GetByVerificationToken(token string) (*models.User, error) {
+	var user models.User
+	if err := r.db.Where("email_verification_token = ?", token).First(&user).Error; err != nil {
+		r.log.Error("failed to get user by verification token", "err", err)
+		return nil, err
+	}
+	return &user, nil
+}
+
 func (r *userRepository) GetAll() ([]models.User, error) {
 	var users []models.User
 	if err := r.db.Find(&users).Error; err != nil {
@@ -75,3 +92,22 @@ func (r *userRepository) Delete(id uint) error {
 	}
 	return nil
 }
+
+func (r *userRepository) MarkErasureCompleted(id uint) error {
+	if err := r.db.Unscoped().
+		Model(&models.User{}).
+		Where("id = ? AND erased_at IS NOT NULL", id).
+		Update("erasure_completed_at", time.Now()).Error; err != nil {
+		r.log.Error("failed to mark erasure completed", "id", id, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (r *userRepository) WithTx(tx *gorm.DB) UserRepository {
+	return &userRepository{db: tx, log: r.log}
+}
+
+func (r *userRepository) Transaction(fn func(tx *gorm.DB) error) error {
+	return r.db.Transaction(fn)
+}
@@ -1,7 +1,10 @@
 package services
 
 import (
+	stderrors "errors"
 	"log/slog"
+	"strings"
+	"time"
 	"user-service/internal/auth"
 	"user-service/internal/dto"
 	"user-service/internal/errors"
@@ -10,28 +13,59 @@ import (
 	"user-service/internal/repository"
 
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
+// dummyHash is compared against when the email is unknown so that a login
+// for a missing account takes as long as one with a wrong password.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+
 type AuthService interface {
 	Register(req dto.RegisterRequest) (*models.User, error)
-	Login(req dto.LoginRequest) (string, error)
+	Login(req dto.LoginRequest, ip string) (string, error)
 }
 
 type authService struct {
-	repo     repository.UserRepository
-	producer *kafka.Producer
-	log      *slog.Logger
+	repo         repository.UserRepository
+	roleRepo     repository.RoleRepository
+	outbox       repository.OutboxRepository
+	producer     *kafka.Producer
+	accountGuard *auth.LoginGuard
+	ipGuard      *auth.LoginGuard
+	log          *slog.Logger
 }
 
-func NewAuthService(repo repository.UserRepository, producer *kafka.Producer, log *slog.Logger) AuthService {
-	return &authService{repo: repo, producer: producer, log: log}
+func NewAuthService(
+	repo repository.UserRepository,
+	roleRepo repository.RoleRepository,
+	outbox repository.OutboxRepository,
+	producer *kafka.Producer,
+	accountGuard *auth.LoginGuard,
+	ipGuard *auth.LoginGuard,
+	log *slog.Logger,
+) AuthService {
+	return &authService{
+		repo:         repo,
+		roleRepo:     roleRepo,
+		outbox:       outbox,
+		producer:     producer,
+		accountGuard: accountGuard,
+		ipGuard:      ipGuard,
+		log:          log,
+	}
 }
 
 func (s *authService) Register(req dto.RegisterRequest) (*models.User, error) {
-	if _, err := s.repo.GetByEmail(req.Email); err == nil {
+	email := normalizeEmail(req.Email)
+	if _, err := s.repo.GetByEmail(email); err == nil {
 		return nil, errors.ErrUserAlreadyExists
 	}
 
+	birthDate, err := parseBirthDate(req.BirthDate)
+	if err != nil {
+		return nil, err
+	}
+
 	hashedPassword, err := bcrypt.GenerateFromPassword(
 		[]byte(req.Password),
 		bcrypt.DefaultCost,
@@ -41,37 +75,88 @@ func (s *authService) Register(req dto.RegisterRequest) (*models.User, error) {
 	}
 
 	user := &models.User{
-		Email:    req.Email,
-		Password: string(hashedPassword),
-		Name:     req.Name,
-		Role:     "user",
+		Email:     email,
+		Password:  string(hashedPassword),
+		Name:      req.Name,
+		Role:      auth.RoleCustomer,
+		BirthDate: birthDate,
 	}
 
-	if err := s.repo.Create(user); err != nil {
+	if err := writeWithEvents(s.repo, s.outbox,
+		func(users repository.UserRepository) error { return users.Create(user) },
+		func() []pendingEvent { return []pendingEvent{userCreatedEvent(user)} },
+	); err != nil {
 		return nil, err
 	}
-	if err := s.producer.SendUserCreated(kafka.UserCreatedEvent{
-		ID:    user.ID,
-		Email: user.Email,
-	}); err != nil {
-		s.log.Error("failed to send user.created event", "user_id", user.ID, "err", err)
-	}
 
 	return user, nil
 }
 
-func (s *authService) Login(req dto.LoginRequest) (string, error) {
-	user, err := s.repo.GetByEmail(req.Email)
+func (s *authService) Login(req dto.LoginRequest, ip string) (string, error) {
+	email := normalizeEmail(req.Email)
+
+	retryAfter := max(s.accountGuard.Locked(email), s.ipGuard.Locked(ip))
+	if retryAfter > 0 {
+		s.log.Warn("login attempt while locked out", "ip", ip, "retry_after", retryAfter)
+		return "", &errors.LockoutError{RetryAfter: retryAfter}
+	}
+
+	user, err := s.repo.GetByEmail(email)
 	if err != nil {
-		return "", err
+		if !stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return "", err
+		}
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(req.Password))
+		return "", s.loginFailed(email, ip)
 	}
 
 	if err := bcrypt.CompareHashAndPassword(
 		[]byte(user.Password),
 		[]byte(req.Password),
 	); err != nil {
+		return "", s.loginFailed(email, ip)
+	}
+
+	s.accountGuard.Reset(email)
+
+	permissions, err := s.roleRepo.Permissions(user.Role)
+	if err != nil {
 		return "", err
 	}
 
-	return auth.GenerateToken(user.ID, user.Role)
+	return auth.GenerateToken(user.ID, user.Role, permissions, user.TokenVersion)
+}
+
+// normalizeEmail is how emails are stored and looked up, so an address
+// matches whatever case it is typed in.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func (s *authService) loginFailed(email, ip string) error {
+	ipFailures, ipLockout := s.ipGuard.Fail(ip)
+	accountFailures, accountLockout := s.accountGuard.Fail(email)
+
+	if accountLockout > 0 {
+		s.publishLockout("account", email, ip, accountFailures, accountLockout)
+	}
+	if ipLockout > 0 {
+		s.publishLockout("ip", "", ip, ipFailures, ipLockout)
+	}
+
+	return errors.ErrInvalidCredentials
+}
+
+func (s *authService) publishLockout(scope, email, ip string, failures int, lockout time.Duration) {
+	s.log.Warn("login locked out", "scope", scope, "email", email, "ip", ip, "failures", failures, "lockout", lockout)
+
+	if err := s.producer.SendLoginLockout(kafka.LoginLockoutEvent{
+		Scope:       scope,
+		Email:       email,
+		IP:          ip,
+		Failures:    failures,
+		LockedUntil: time.Now().Add(lockout),
+	}); err != nil {
+		s.log.Error("failed to send auth.lockout event", "scope", scope, "ip", ip, "err", err)
+	}
 }
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"user-service/internal/events"
+	"user-service/internal/models"
+	"user-service/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type pendingEvent struct {
+	eventType string
+	userID    uint
+	data      any
+}
+
+// writeWithEvents runs write in a transaction and records the events in the
+// outbox within the same transaction, so an event exists iff the change does.
+// The events are built after write so they can use ids assigned by it.
+func writeWithEvents(
+	users repository.UserRepository,
+	outbox repository.OutboxRepository,
+	write func(users repository.UserRepository) error,
+	build func() []pendingEvent,
+) error {
+	return users.Transaction(func(tx *gorm.DB) error {
+		if err := write(users.WithTx(tx)); err != nil {
+			return err
+		}
+
+		pending := build()
+		records := make([]models.OutboxEvent, 0, len(pending))
+		for _, p := range pending {
+			record, err := events.New(p.eventType, p.userID, p.data)
+			if err != nil {
+				return err
+			}
+			records = append(records, record)
+		}
+
+		return outbox.Add(tx, records...)
+	})
+}
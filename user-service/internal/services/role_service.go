@@ -0,0 +1,60 @@
+package services
+
+import (
+	"log/slog"
+	"slices"
+	"user-service/internal/auth"
+	"user-service/internal/dto"
+	"user-service/internal/errors"
+	"user-service/internal/repository"
+)
+
+type RoleService interface {
+	List() ([]dto.RoleResponse, error)
+	SetPermissions(role string, req dto.SetRolePermissionsRequest) (*dto.RoleResponse, error)
+}
+
+type roleService struct {
+	repo repository.RoleRepository
+	log  *slog.Logger
+}
+
+func NewRoleService(repo repository.RoleRepository, log *slog.Logger) RoleService {
+	return &roleService{repo: repo, log: log}
+}
+
+func (s *roleService) List() ([]dto.RoleResponse, error) {
+	all, err := s.repo.All()
+	if err != nil {
+		s.log.Error("failed to list roles", "err", err)
+		return nil, err
+	}
+
+	roles := make([]dto.RoleResponse, 0, len(auth.Roles))
+	for _, name := range auth.Roles {
+		perms := all[name]
+		if perms == nil {
+			perms = []string{}
+		}
+		roles = append(roles, dto.RoleResponse{Name: name, Permissions: perms})
+	}
+	return roles, nil
+}
+
+func (s *roleService) SetPermissions(role string, req dto.SetRolePermissionsRequest) (*dto.RoleResponse, error) {
+	if !auth.IsValidRole(role) {
+		return nil, errors.ErrUnknownRole
+	}
+
+	perms := slices.Clone(req.Permissions)
+	slices.Sort(perms)
+	perms = slices.Compact(perms)
+
+	if err := s.repo.SetPermissions(role, perms); err != nil {
+		s.log.Error("failed to set role permissions", "role", role, "err", err)
+		return nil, err
+	}
+
+	s.log.Info("role permissions updated", "role", role, "permissions", perms)
+	return &dto.RoleResponse{Name: role, Permissions: perms}, nil
+}
@@ -1,9 +1,19 @@
 package services
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"log/slog"
+	"time"
+	"user-service/internal/auth"
+	"user-service/internal/clients"
 	"user-service/internal/dto"
+	apperrors "user-service/internal/errors"
+	"user-service/internal/events"
+	"user-service/internal/kafka"
 	"user-service/internal/models"
 	"user-service/internal/repository"
 
@@ -17,15 +27,34 @@ type UserService interface {
 	List() ([]models.User, error)
 	Update(id uint, req dto.UpdateUserRequest) (*models.User, error)
 	Delete(id uint) error
+
+	UpdateProfile(id uint, req dto.UpdateProfileRequest) (*models.User, error)
+	ChangePassword(id uint, req dto.ChangePasswordRequest) error
+	DeleteAccount(id uint, req dto.DeleteAccountRequest) error
+	VerifyEmail(token string) (*models.User, error)
+
+	Export(id uint) (*dto.UserExport, error)
+	Erase(id uint) error
+	EraseAccount(id uint, req dto.DeleteAccountRequest) error
+	CompleteErasure(id uint) error
 }
 
+const emailVerificationTTL = 24 * time.Hour
+
 type userService struct {
-	repo repository.UserRepository
-	log  *slog.Logger
+	repo     repository.UserRepository
+	outbox   repository.OutboxRepository
+	producer *kafka.Producer
+	log      *slog.Logger
 }
 
-func NewUserService(repo repository.UserRepository, log *slog.Logger) UserService {
-	return &userService{repo: repo, log: log}
+func NewUserService(
+	repo repository.UserRepository,
+	outbox repository.OutboxRepository,
+	producer *kafka.Producer,
+	log *slog.Logger,
+) UserService {
+	return &userService{repo: repo, outbox: outbox, producer: producer, log: log}
 }
 
 func (s *userService) Create(req dto.CreateUserRequest) (*models.User, error) {
@@ -37,18 +66,27 @@ func (s *userService) Create(req dto.CreateUserRequest) (*models.User, error) {
 		return nil, err
 	}
 
+	birthDate, err := parseBirthDate(req.BirthDate)
+	if err != nil {
+		return nil, err
+	}
+
 	user := &models.User{
-		Email:    req.Email,
-		Password: string(hashedPassword),
-		Name:     req.Name,
-		Role:     req.Role,
+		Email:     normalizeEmail(req.Email),
+		Password:  string(hashedPassword),
+		Name:      req.Name,
+		Role:      req.Role,
+		BirthDate: birthDate,
 	}
 
 	if user.Role == "" {
-		user.Role = "user"
+		user.Role = auth.RoleCustomer
 	}
 
-	if err := s.repo.Create(user); err != nil {
+	if err := writeWithEvents(s.repo, s.outbox,
+		func(users repository.UserRepository) error { return users.Create(user) },
+		func() []pendingEvent { return []pendingEvent{userCreatedEvent(user)} },
+	); err != nil {
 		s.log.Error("failed to create user", "email", user.Email, "err", err)
 		return nil, err
 	}
@@ -90,15 +128,53 @@ func (s *userService) Update(id uint, req dto.UpdateUserRequest) (*models.User,
 		return nil, err
 	}
 
-	if req.Email != nil {
-		user.Email = *req.Email
+	var fields []string
+	oldRole := user.Role
+
+	if req.Email != nil && normalizeEmail(*req.Email) != user.Email {
+		email := normalizeEmail(*req.Email)
+		if err := s.ensureEmailFree(email); err != nil {
+			return nil, err
+		}
+		user.Email = email
+		fields = append(fields, "email")
+	}
+
+	if req.Name != nil && *req.Name != user.Name {
+		user.Name = *req.Name
+		fields = append(fields, "name")
+	}
+
+	if req.BirthDate != nil {
+		changed, err := setBirthDate(user, *req.BirthDate)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			fields = append(fields, "birth_date")
+		}
 	}
 
 	if req.Role != nil {
 		user.Role = *req.Role
 	}
 
-	if err := s.repo.Update(user); err != nil {
+	if err := writeWithEvents(s.repo, s.outbox,
+		func(users repository.UserRepository) error { return users.Update(user) },
+		func() []pendingEvent {
+			var pending []pendingEvent
+			if len(fields) > 0 {
+				pending = append(pending, userUpdatedEvent(user, fields))
+			}
+			if user.Role != oldRole {
+				pending = append(pending, pendingEvent{events.UserRoleChanged, user.ID, events.UserRoleChangedData{
+					OldRole: oldRole,
+					NewRole: user.Role,
+				}})
+			}
+			return pending
+		},
+	); err != nil {
 		s.log.Error("failed to update user", "id", id, "err", err)
 		return nil, err
 	}
@@ -107,7 +183,7 @@ func (s *userService) Update(id uint, req dto.UpdateUserRequest) (*models.User,
 }
 
 func (s *userService) Delete(id uint) error {
-	_, err := s.repo.GetByID(id)
+	user, err := s.repo.GetByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			s.log.Warn("user not found for delete", "id", id)
@@ -117,10 +193,351 @@ func (s *userService) Delete(id uint) error {
 		return err
 	}
 
-	if err := s.repo.Delete(id); err != nil {
+	if err := s.deleteUser(user); err != nil {
 		s.log.Error("failed to delete user", "id", id, "err", err)
 		return err
 	}
 
 	return nil
 }
+
+func (s *userService) UpdateProfile(id uint, req dto.UpdateProfileRequest) (*models.User, error) {
+	user, err := s.repo.GetByID(id)
+	if err != nil {
+		s.log.Error("failed to get user for profile update", "id", id, "err", err)
+		return nil, err
+	}
+
+	var fields []string
+	if req.Name != nil && *req.Name != user.Name {
+		user.Name = *req.Name
+		fields = append(fields, "name")
+	}
+
+	// the age check for bookings relies on the birth date, so users may only
+	// set a missing one; corrections go through PUT /users/:id
+	if req.BirthDate != nil {
+		if user.BirthDate != nil && *req.BirthDate != user.BirthDateString() {
+			return nil, apperrors.ErrBirthDateLocked
+		}
+		changed, err := setBirthDate(user, *req.BirthDate)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			fields = append(fields, "birth_date")
+		}
+	}
+
+	var token string
+	if req.Email != nil && normalizeEmail(*req.Email) != user.Email {
+		email := normalizeEmail(*req.Email)
+		if err := s.ensureEmailFree(email); err != nil {
+			return nil, err
+		}
+
+		token, err = newVerificationToken()
+		if err != nil {
+			s.log.Error("failed to generate verification token", "id", id, "err", err)
+			return nil, err
+		}
+
+		expires := time.Now().Add(emailVerificationTTL)
+		user.PendingEmail = email
+		user.EmailVerificationToken = hashToken(token)
+		user.EmailVerificationExpires = &expires
+	}
+
+	if err := writeWithEvents(s.repo, s.outbox,
+		func(users repository.UserRepository) error { return users.Update(user) },
+		func() []pendingEvent {
+			if len(fields) == 0 {
+				return nil
+			}
+			return []pendingEvent{userUpdatedEvent(user, fields)}
+		},
+	); err != nil {
+		s.log.Error("failed to update profile", "id", id, "err", err)
+		return nil, err
+	}
+
+	if token != "" {
+		if err := s.producer.SendEmailVerification(kafka.EmailVerificationEvent{
+			ID:        user.ID,
+			Email:     user.PendingEmail,
+			Token:     token,
+			ExpiresAt: *user.EmailVerificationExpires,
+		}); err != nil {
+			s.log.Error("failed to send email verification event", "user_id", user.ID, "err", err)
+		}
+	}
+
+	return user, nil
+}
+
+func (s *userService) ChangePassword(id uint, req dto.ChangePasswordRequest) error {
+	user, err := s.repo.GetByID(id)
+	if err != nil {
+		s.log.Error("failed to get user for password change", "id", id, "err", err)
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.CurrentPassword)); err != nil {
+		s.log.Warn("password change rejected: wrong current password", "id", id)
+		return apperrors.ErrInvalidPassword
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		s.log.Error("failed to hash password", "err", err)
+		return err
+	}
+
+	user.Password = string(hashedPassword)
+	// tokens issued with the old password stop working
+	user.TokenVersion++
+	if err := writeWithEvents(s.repo, s.outbox,
+		func(users repository.UserRepository) error { return users.Update(user) },
+		func() []pendingEvent { return []pendingEvent{userUpdatedEvent(user, []string{"password"})} },
+	); err != nil {
+		s.log.Error("failed to change password", "id", id, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *userService) DeleteAccount(id uint, req dto.DeleteAccountRequest) error {
+	user, err := s.repo.GetByID(id)
+	if err != nil {
+		s.log.Error("failed to get user for account deletion", "id", id, "err", err)
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		s.log.Warn("account deletion rejected: wrong password", "id", id)
+		return apperrors.ErrInvalidPassword
+	}
+
+	if err := s.deleteUser(user); err != nil {
+		s.log.Error("failed to delete account", "id", id, "err", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *userService) VerifyEmail(token string) (*models.User, error) {
+	user, err := s.repo.GetByVerificationToken(hashToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	if user.EmailVerificationExpires == nil || time.Now().After(*user.EmailVerificationExpires) {
+		s.log.Warn("expired email verification token", "id", user.ID)
+		return nil, apperrors.ErrInvalidToken
+	}
+
+	if err := s.ensureEmailFree(user.PendingEmail); err != nil {
+		return nil, err
+	}
+
+	user.Email = user.PendingEmail
+	user.PendingEmail = ""
+	user.EmailVerificationToken = ""
+	user.EmailVerificationExpires = nil
+
+	if err := writeWithEvents(s.repo, s.outbox,
+		func(users repository.UserRepository) error { return users.Update(user) },
+		func() []pendingEvent {
+			return []pendingEvent{{events.UserVerified, user.ID, events.UserVerifiedData{Email: user.Email}}}
+		},
+	); err != nil {
+		s.log.Error("failed to apply verified email", "id", user.ID, "err", err)
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *userService) Export(id uint) (*dto.UserExport, error) {
+	user, err := s.repo.GetByID(id)
+	if err != nil {
+		s.log.Error("failed to get user for export", "id", id, "err", err)
+		return nil, err
+	}
+
+	bookings, err := clients.GetUserBookings(id)
+	if err != nil {
+		s.log.Error("failed to get bookings for export", "id", id, "err", err)
+		return nil, err
+	}
+
+	return &dto.UserExport{
+		ExportedAt: time.Now().UTC(),
+		Profile: dto.ProfileExport{
+			ID:           user.ID,
+			Email:        user.Email,
+			Name:         user.Name,
+			Role:         user.Role,
+			BirthDate:    user.BirthDateString(),
+			PendingEmail: user.PendingEmail,
+			CreatedAt:    user.CreatedAt,
+			UpdatedAt:    user.UpdatedAt,
+		},
+		Bookings: bookings,
+	}, nil
+}
+
+func (s *userService) EraseAccount(id uint, req dto.DeleteAccountRequest) error {
+	user, err := s.repo.GetByID(id)
+	if err != nil {
+		s.log.Error("failed to get user for erasure", "id", id, "err", err)
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		s.log.Warn("erasure rejected: wrong password", "id", id)
+		return apperrors.ErrInvalidPassword
+	}
+
+	return s.erase(user)
+}
+
+func (s *userService) Erase(id uint) error {
+	user, err := s.repo.GetByID(id)
+	if err != nil {
+		s.log.Error("failed to get user for erasure", "id", id, "err", err)
+		return err
+	}
+
+	return s.erase(user)
+}
+
+// erase anonymizes the user in place and soft-deletes it. Other services are
+// told through user.erased to swap the user id for a random pseudonym.
+func (s *userService) erase(user *models.User) error {
+	pseudonym, err := newVerificationToken()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	user.Email = fmt.Sprintf("erased-%d@erased.invalid", user.ID)
+	user.Name = "Erased user"
+	user.Password = ""
+	user.BirthDate = nil
+	user.PendingEmail = ""
+	user.EmailVerificationToken = ""
+	user.EmailVerificationExpires = nil
+	user.ErasedAt = &now
+
+	if err := writeWithEvents(s.repo, s.outbox,
+		func(users repository.UserRepository) error {
+			if err := users.Update(user); err != nil {
+				return err
+			}
+			return users.Delete(user.ID)
+		},
+		func() []pendingEvent {
+			return []pendingEvent{{events.UserErased, user.ID, events.UserErasedData{Pseudonym: pseudonym}}}
+		},
+	); err != nil {
+		s.log.Error("failed to erase user", "id", user.ID, "err", err)
+		return err
+	}
+
+	s.log.Info("user erased", "id", user.ID)
+	return nil
+}
+
+func (s *userService) CompleteErasure(id uint) error {
+	if err := s.repo.MarkErasureCompleted(id); err != nil {
+		return err
+	}
+
+	s.log.Info("user erasure completed in all services", "id", id)
+	return nil
+}
+
+func (s *userService) deleteUser(user *models.User) error {
+	return writeWithEvents(s.repo, s.outbox,
+		func(users repository.UserRepository) error { return users.Delete(user.ID) },
+		func() []pendingEvent {
+			return []pendingEvent{{events.UserDeleted, user.ID, events.UserDeletedData{Email: user.Email}}}
+		},
+	)
+}
+
+func userCreatedEvent(user *models.User) pendingEvent {
+	return pendingEvent{events.UserCreated, user.ID, events.UserCreatedData{
+		Email:     user.Email,
+		Name:      user.Name,
+		Role:      user.Role,
+		BirthDate: user.BirthDateString(),
+	}}
+}
+
+func userUpdatedEvent(user *models.User, fields []string) pendingEvent {
+	return pendingEvent{events.UserUpdated, user.ID, events.UserUpdatedData{
+		Email:     user.Email,
+		Name:      user.Name,
+		BirthDate: user.BirthDateString(),
+		Fields:    fields,
+	}}
+}
+
+// parseBirthDate reads an optional "2006-01-02" birth date and rejects dates
+// that are not in the past.
+func parseBirthDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(models.DateLayout, value)
+	if err != nil || !date.Before(time.Now()) {
+		return nil, apperrors.ErrInvalidBirthDate
+	}
+	return &date, nil
+}
+
+// setBirthDate applies a birth date from an update request; "" clears it.
+// It reports whether the stored value changed.
+func setBirthDate(user *models.User, value string) (bool, error) {
+	date, err := parseBirthDate(value)
+	if err != nil {
+		return false, err
+	}
+	if user.BirthDateString() == value {
+		return false, nil
+	}
+	user.BirthDate = date
+	return true, nil
+}
+
+func (s *userService) ensureEmailFree(email string) error {
+	_, err := s.repo.GetByEmail(email)
+	if err == nil {
+		return apperrors.ErrUserAlreadyExists
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+func newVerificationToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -1,7 +1,10 @@
 package transport
 
 import (
+	stderrors "errors"
+	"math"
 	"net/http"
+	"strconv"
 	"user-service/internal/dto"
 	"user-service/internal/errors"
 	"user-service/internal/services"
@@ -35,6 +38,12 @@ func (h *AuthHandler) Register(c *gin.Context) {
 			})
 			return
 		}
+		if err == errors.ErrInvalidBirthDate {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
 
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
@@ -50,6 +59,11 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	})
 }
 
+// Check answers 204 for a token JWTMiddleware accepts.
+func (h *AuthHandler) Check(c *gin.Context) {
+	c.Status(http.StatusNoContent)
+}
+
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req dto.LoginRequest
 
@@ -58,8 +72,15 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	token, err := h.service.Login(req)
+	token, err := h.service.Login(req, c.ClientIP())
 	if err != nil {
+		var lockout *errors.LockoutError
+		if stderrors.As(err, &lockout) {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(lockout.RetryAfter.Seconds()))))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "too many login attempts, try again later"})
+			return
+		}
+
 		c.JSON(401, gin.H{"error": "invalid email or password"})
 		return
 	}
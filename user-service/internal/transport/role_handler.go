@@ -0,0 +1,53 @@
+package transport
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"user-service/internal/dto"
+	apperrors "user-service/internal/errors"
+	"user-service/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoleHandler struct {
+	service services.RoleService
+	log     *slog.Logger
+}
+
+func NewRoleHandler(service services.RoleService, log *slog.Logger) *RoleHandler {
+	return &RoleHandler{service: service, log: log}
+}
+
+func (h *RoleHandler) List(c *gin.Context) {
+	roles, err := h.service.List()
+	if err != nil {
+		h.log.Error("failed to list roles", "err", err)
+		c.JSON(500, gin.H{"error": "internal error"})
+		return
+	}
+	c.JSON(200, roles)
+}
+
+func (h *RoleHandler) SetPermissions(c *gin.Context) {
+	var req dto.SetRolePermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Warn("invalid set role permissions request", "err", err)
+		c.JSON(400, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	role, err := h.service.SetPermissions(c.Param("name"), req)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUnknownRole) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		h.log.Error("failed to set role permissions", "role", c.Param("name"), "err", err)
+		c.JSON(500, gin.H{"error": "internal error"})
+		return
+	}
+
+	c.JSON(200, role)
+}
@@ -1,46 +1,71 @@
 package transport
 
 import (
+	"shared/authz"
 	"user-service/internal/middleware"
+	"user-service/internal/repository"
 
 	"github.com/gin-gonic/gin"
 )
 
 func RegisterRouters(r *gin.Engine,
-	auth *AuthHandler,
+	authHandler *AuthHandler,
 	users *UserHandler,
+	roles *RoleHandler,
+	userRepo repository.UserRepository,
 ) {
+	authenticate := middleware.JWTMiddleware(userRepo)
+
 	{
 		authGroup := r.Group("/auth")
 
-		authGroup.POST("/register", auth.Register)
-		authGroup.POST("/login", auth.Login)
+		authGroup.POST("/register", authHandler.Register)
+		authGroup.POST("/login", authHandler.Login)
+		authGroup.POST("/verify-email", users.VerifyEmail)
+		// the gateway asks whether a token is still current before
+		// proxying to the other services
+		authGroup.GET("/check", authenticate, authHandler.Check)
 	}
 
 	admin := r.Group("/admin")
-	admin.Use(middleware.JWTMiddleware())
-	admin.Use(middleware.AdminMiddleware())
+	admin.Use(authenticate)
+	admin.Use(authz.RequirePermission(authz.PermUsersWrite))
 	{
 		admin.DELETE("/:id", users.Delete)
 		admin.PUT("/:id", users.Update)
+		admin.POST("/:id/erase", users.Erase)
+	}
+
+	roleGroup := r.Group("/roles")
+	roleGroup.Use(authenticate)
+	{
+		roleGroup.GET("", authz.RequirePermission(authz.PermUsersRead), roles.List)
+		roleGroup.PUT("/:name", authz.RequirePermission(authz.PermRolesWrite), roles.SetPermissions)
 	}
 
 	user := r.Group("/users")
-	user.Use(middleware.JWTMiddleware())
+	user.Use(authenticate)
 
 	{
-		user.GET("", users.List)
-		user.GET("/:id", users.Get)
-		user.DELETE("/:id", users.Delete)
-		user.PUT("/:id", users.Update)
+		user.GET("", authz.RequirePermission(authz.PermUsersRead), users.List)
+		user.GET("/:id", middleware.OwnerOrPermission(authz.PermUsersRead), users.Get)
+		// Owners change or delete their own account through /me, which
+		// re-verifies email changes and asks for the password on delete.
+		user.DELETE("/:id", authz.RequirePermission(authz.PermUsersWrite), users.Delete)
+		user.PUT("/:id", authz.RequirePermission(authz.PermUsersWrite), users.Update)
 	}
 
 	protected := r.Group("")
-	protected.Use(middleware.JWTMiddleware())
+	protected.Use(authenticate)
 
 	{
 		protected.GET("/me", users.Me)
+		protected.PATCH("/me", users.UpdateMe)
+		protected.DELETE("/me", users.DeleteMe)
+		protected.PUT("/me/password", users.ChangePassword)
 		protected.GET("/me/bookings", users.MyBookings)
+		protected.GET("/me/export", users.ExportMe)
+		protected.POST("/me/erase", users.EraseMe)
 	}
 
 }
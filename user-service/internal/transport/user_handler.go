@@ -1,11 +1,14 @@
 package transport
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
+	"shared/authz"
 	"strconv"
 	"user-service/internal/config"
 	"user-service/internal/dto"
+	apperrors "user-service/internal/errors"
 	"user-service/internal/models"
 	"user-service/internal/services"
 
@@ -42,6 +45,10 @@ func (h *UserHandler) Create(c *gin.Context) {
 
 	user, err := h.service.Create(req)
 	if err != nil {
+		if errors.Is(err, apperrors.ErrInvalidBirthDate) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		h.log.Error("failed to create user", "email", req.Email, "err", err)
 		c.JSON(500, gin.H{"error": "internal error"})
 		return
@@ -95,8 +102,22 @@ func (h *UserHandler) Update(c *gin.Context) {
 		return
 	}
 
+	if req.Role != nil && !authz.HasPermission(c, authz.PermRolesWrite) {
+		h.log.Warn("role change without permission", "id", id, "user_id", c.GetUint("user_id"))
+		authz.Forbid(c, authz.PermRolesWrite)
+		return
+	}
+
 	user, err := h.service.Update(uint(id), req)
 	if err != nil {
+		if errors.Is(err, apperrors.ErrUserAlreadyExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": "email already exists"})
+			return
+		}
+		if errors.Is(err, apperrors.ErrInvalidBirthDate) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		h.log.Warn("user not found for update", "id", id)
 		c.JSON(404, gin.H{"error": "not found"})
 		return
@@ -126,6 +147,9 @@ func toUserResponse(u *models.User) dto.UserResponse {
 		Email: u.Email,
 		Name:  u.Name,
 		Role:  u.Role,
+
+		BirthDate:    u.BirthDateString(),
+		PendingEmail: u.PendingEmail,
 	}
 }
 
@@ -142,6 +166,162 @@ func (h *UserHandler) Me(c *gin.Context) {
 	c.JSON(200, toUserResponse(user))
 }
 
+func (h *UserHandler) UpdateMe(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	var req dto.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Warn("invalid update profile request", "user_id", userID, "err", err)
+		c.JSON(400, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	user, err := h.service.UpdateProfile(userID, req)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserAlreadyExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": "email already exists"})
+			return
+		}
+		if errors.Is(err, apperrors.ErrInvalidBirthDate) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, apperrors.ErrBirthDateLocked) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		h.log.Error("failed to update profile", "user_id", userID, "err", err)
+		c.JSON(500, gin.H{"error": "internal error"})
+		return
+	}
+
+	c.JSON(200, toUserResponse(user))
+}
+
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Warn("invalid change password request", "user_id", userID, "err", err)
+		c.JSON(400, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if err := h.service.ChangePassword(userID, req); err != nil {
+		if errors.Is(err, apperrors.ErrInvalidPassword) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		h.log.Error("failed to change password", "user_id", userID, "err", err)
+		c.JSON(500, gin.H{"error": "internal error"})
+		return
+	}
+
+	c.Status(204)
+}
+
+func (h *UserHandler) DeleteMe(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	var req dto.DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Warn("invalid delete account request", "user_id", userID, "err", err)
+		c.JSON(400, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if err := h.service.DeleteAccount(userID, req); err != nil {
+		if errors.Is(err, apperrors.ErrInvalidPassword) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "password is incorrect"})
+			return
+		}
+		h.log.Error("failed to delete account", "user_id", userID, "err", err)
+		c.JSON(500, gin.H{"error": "internal error"})
+		return
+	}
+
+	c.Status(204)
+}
+
+func (h *UserHandler) VerifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	user, err := h.service.VerifyEmail(req.Token)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrInvalidToken):
+			c.JSON(400, gin.H{"error": err.Error()})
+		case errors.Is(err, apperrors.ErrUserAlreadyExists):
+			c.JSON(http.StatusConflict, gin.H{"error": "email already exists"})
+		default:
+			h.log.Error("failed to verify email", "err", err)
+			c.JSON(500, gin.H{"error": "internal error"})
+		}
+		return
+	}
+
+	c.JSON(200, toUserResponse(user))
+}
+
+func (h *UserHandler) ExportMe(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	export, err := h.service.Export(userID)
+	if err != nil {
+		h.log.Error("failed to export user data", "user_id", userID, "err", err)
+		c.JSON(500, gin.H{"error": "failed to export user data"})
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=\"user-"+strconv.Itoa(int(userID))+"-export.json\"")
+	c.JSON(200, export)
+}
+
+func (h *UserHandler) EraseMe(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	var req dto.DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Warn("invalid erase account request", "user_id", userID, "err", err)
+		c.JSON(400, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if err := h.service.EraseAccount(userID, req); err != nil {
+		if errors.Is(err, apperrors.ErrInvalidPassword) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "password is incorrect"})
+			return
+		}
+		h.log.Error("failed to erase account", "user_id", userID, "err", err)
+		c.JSON(500, gin.H{"error": "internal error"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "erasure started"})
+}
+
+func (h *UserHandler) Erase(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.log.Warn("invalid user id for erase", "id", c.Param("id"))
+		c.JSON(400, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	if err := h.service.Erase(uint(id)); err != nil {
+		h.log.Warn("user not found for erase", "id", id)
+		c.JSON(404, gin.H{"error": "not found"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "erasure started"})
+}
+
 func (h *UserHandler) MyBookings(c *gin.Context) {
 	userID := c.GetUint("user_id")
 